@@ -0,0 +1,131 @@
+package main
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// CLI access levels reported by "show cli level", lowest first.
+const (
+	levelUser     = "user"
+	levelOperator = "operator"
+	levelAdmin    = "admin"
+)
+
+type cliLevelMsg string
+
+// actionLevels maps, per tab, the keys that send mutating commands to the
+// CLI level they require.
+var actionLevels = map[tab]map[string]string{
+	statsTab: {
+		"d": levelAdmin,
+		"D": levelAdmin,
+		"e": levelAdmin,
+		"R": levelAdmin,
+		"x": levelAdmin,
+		"w": levelAdmin,
+		"W": levelAdmin,
+		"m": levelAdmin,
+		"L": levelAdmin,
+		"H": levelAdmin,
+		"u": levelAdmin,
+		"c": levelOperator,
+		"O": levelAdmin,
+	},
+	rollingTab: {
+		"c": levelAdmin,
+	},
+	mapsTab: {
+		"a": levelOperator,
+		"e": levelOperator,
+		"x": levelOperator,
+		"C": levelOperator,
+		"F": levelOperator,
+	},
+	sessionsTab: {
+		"x": levelAdmin,
+	},
+	crtListsTab: {
+		"a": levelAdmin,
+		"x": levelAdmin,
+		"n": levelAdmin,
+	},
+	tracesTab: {
+		"S": levelAdmin,
+		"X": levelAdmin,
+	},
+	caFilesTab: {
+		"U": levelAdmin,
+		"C": levelAdmin,
+		"A": levelAdmin,
+	},
+	certsTab: {
+		"u": levelAdmin,
+		"U": levelAdmin,
+		"D": levelAdmin,
+		"C": levelAdmin,
+		"A": levelAdmin,
+	},
+	stickTablesTab: {
+		"x": levelOperator,
+		"e": levelOperator,
+	},
+	aclTab: {
+		"a": levelOperator,
+		"x": levelOperator,
+		"C": levelOperator,
+		"F": levelOperator,
+	},
+}
+
+func levelRank(level string) int {
+	switch level {
+	case levelUser:
+		return 1
+	case levelOperator:
+		return 2
+	case levelAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// parseCLILevel returns the level from a "show cli level" reply, or "" when
+// HAProxy doesn't know the command.
+func parseCLILevel(reply string) string {
+	level := strings.TrimSpace(reply)
+	if levelRank(level) == 0 {
+		return ""
+	}
+	return level
+}
+
+func fetchCLILevel(cfg Config) tea.Msg {
+	return cliLevelMsg(parseCLILevel(execCommand(cfg, "show cli level")))
+}
+
+// actionBlockedReason explains why an action requiring level cannot run, or
+// returns "" when it can. An undetected CLI level is not held against the
+// user; HAProxy will still refuse what the socket may not do.
+func (m model) actionBlockedReason(level string) string {
+	if m.config.readOnly {
+		return "read-only mode"
+	}
+	if m.cliLevel != "" && levelRank(m.cliLevel) < levelRank(level) {
+		return "socket level is " + m.cliLevel + ", needs " + level
+	}
+	return ""
+}
+
+// accessBadge is shown next to the socket path when actions are restricted.
+func (m model) accessBadge() string {
+	if m.config.readOnly {
+		return "read-only"
+	}
+	if m.cliLevel != "" && m.cliLevel != levelAdmin {
+		return m.cliLevel
+	}
+	return ""
+}
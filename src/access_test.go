@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseCLILevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		expected string
+	}{
+		{name: "admin", reply: "admin\n\n", expected: levelAdmin},
+		{name: "operator", reply: "operator\n", expected: levelOperator},
+		{name: "unknown command", reply: "Unknown command: 'show cli level'\n", expected: ""},
+		{name: "connection error", reply: "Error: dial unix: no such file", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseCLILevel(tt.reply)
+			if result != tt.expected {
+				t.Errorf("parseCLILevel(%q) = %q; want %q", tt.reply, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestActionBlockedReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		readOnly bool
+		cliLevel string
+		required string
+		blocked  bool
+	}{
+		{name: "admin socket", cliLevel: levelAdmin, required: levelAdmin, blocked: false},
+		{name: "undetected level", cliLevel: "", required: levelAdmin, blocked: false},
+		{name: "operator needs admin", cliLevel: levelOperator, required: levelAdmin, blocked: true},
+		{name: "operator action", cliLevel: levelOperator, required: levelOperator, blocked: false},
+		{name: "read-only", readOnly: true, cliLevel: levelAdmin, required: levelOperator, blocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{config: Config{readOnly: tt.readOnly}, cliLevel: tt.cliLevel}
+			reason := m.actionBlockedReason(tt.required)
+			if (reason != "") != tt.blocked {
+				t.Errorf("actionBlockedReason(%q) = %q; want blocked=%v", tt.required, reason, tt.blocked)
+			}
+		})
+	}
+}
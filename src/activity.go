@@ -0,0 +1,85 @@
+package main
+
+import (
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/activity"
+)
+
+// activityList is the state of the Activity tab: the metrics of the last
+// two refreshes, to show what each thread did in between.
+type activityList struct {
+	metrics  []activity.Metric
+	previous []activity.Metric
+	absolute bool   // show totals rather than per-interval deltas
+	orderBy  string // metric the thread columns are ordered by
+	sort     tableSort
+	raw      bool // show the raw dump rather than the matrix
+}
+
+// handleActivity stores the metrics of a refresh.
+func (m *model) handleActivity(output string) {
+	m.activity = output
+	m.activityList.previous = m.activityList.metrics
+	m.activityList.metrics = activity.ParseMatrix(output)
+	if m.activeTab == activityTab && !m.activityList.raw {
+		// Thread columns follow the order metric, so rebuild them
+		m.showActivity()
+	}
+}
+
+// threadOrder returns the thread indexes in column order.
+func (v activityList) threadOrder() []int {
+	return activity.ThreadOrder(v.metrics, v.previous, v.orderBy)
+}
+
+// showActivity sets up the matrix table, keeping the selected row.
+func (m *model) showActivity() {
+	cursor := m.table.Cursor()
+	m.table = activity.InitializeTable(m.activityList.threadOrder())
+	m.applyTableSize()
+	m.applyFilter()
+	m.table.SetCursor(cursor)
+}
+
+func (m model) activityRows() []table.Row {
+	v := m.activityList
+	return v.sort.apply(activity.Rows(v.metrics, v.previous, v.threadOrder(), v.absolute))
+}
+
+// updateActivityKeys handles the keys of the Activity tab. handled is false
+// for keys left to the common handling.
+func (m *model) updateActivityKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	switch msg.String() {
+	case "v":
+		m.activityList.raw = !m.activityList.raw
+		m.switchTab(activityTab)
+		return nil, true
+	}
+	if m.activityList.raw {
+		return nil, false
+	}
+	switch msg.String() {
+	case "d":
+		m.activityList.absolute = !m.activityList.absolute
+		m.applyFilter()
+		return nil, true
+	case "s":
+		m.activityList.sort.cycle(len(m.table.Columns()))
+		m.applyFilter()
+		return nil, true
+	case "enter":
+		row := m.table.SelectedRow()
+		if len(row) == 0 {
+			return nil, true
+		}
+		if m.activityList.orderBy == row[0] {
+			m.activityList.orderBy = ""
+		} else {
+			m.activityList.orderBy = row[0]
+		}
+		m.showActivity()
+		return nil, true
+	}
+	return nil, false
+}
@@ -26,10 +26,6 @@ func fetchSessions(cfg Config) tea.Msg {
 	return sessionMsg(execCommand(cfg, "show sess"))
 }
 
-func fetchCerts(cfg Config) tea.Msg {
-	return certsMsg(execCommand(cfg, "show ssl cert"))
-}
-
 func fetchThreads(cfg Config) tea.Msg {
 	return threadsMsg(execCommand(cfg, "show threads"))
 }
@@ -43,57 +39,44 @@ func fetchEvents(cfg Config) tea.Msg {
 }
 
 func disableServer(cfg Config, backend, server string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := fmt.Sprintf("disable server %s/%s", backend, server)
-		execCommand(cfg, cmd)
-		return fetchStats(cfg)
-	}
+	cmd := fmt.Sprintf("disable server %s/%s", backend, server)
+	return trackedAction(cfg, cmd, []serverRef{{backend, server}}, cmd)
 }
 
 func enableServer(cfg Config, backend, server string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := fmt.Sprintf("enable server %s/%s", backend, server)
-		execCommand(cfg, cmd)
-		return fetchStats(cfg)
-	}
+	cmd := fmt.Sprintf("enable server %s/%s", backend, server)
+	return trackedAction(cfg, cmd, []serverRef{{backend, server}}, cmd)
 }
 
 func drainServer(cfg Config, backend, server string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := fmt.Sprintf("set server %s/%s state drain", backend, server)
-		execCommand(cfg, cmd)
-		return fetchStats(cfg)
-	}
+	cmd := fmt.Sprintf("set server %s/%s state drain", backend, server)
+	return trackedAction(cfg, cmd, []serverRef{{backend, server}}, cmd)
 }
 
 func readyServer(cfg Config, backend, server string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := fmt.Sprintf("set server %s/%s state ready", backend, server)
-		execCommand(cfg, cmd)
-		return fetchStats(cfg)
-	}
+	cmd := fmt.Sprintf("set server %s/%s state ready", backend, server)
+	return trackedAction(cfg, cmd, []serverRef{{backend, server}}, cmd)
 }
 
 func killServerSessions(cfg Config, backend, server string) tea.Cmd {
 	return func() tea.Msg {
 		cmd := fmt.Sprintf("shutdown sessions server %s/%s", backend, server)
-		execCommand(cfg, cmd)
+		execAction(cfg, cmd)
 		return fetchStats(cfg)
 	}
 }
 
 func clearCounters(cfg Config) tea.Cmd {
 	return func() tea.Msg {
-		execCommand(cfg, "clear counters")
+		execAction(cfg, "clear counters")
 		return fetchStats(cfg)
 	}
 }
 
-func setServerWeight(cfg Config, backend, server string, weight int) tea.Cmd {
+// runServerCommand sends cmd and reports HAProxy's reply back to the model.
+func runServerCommand(cfg Config, cmd string) tea.Cmd {
 	return func() tea.Msg {
-		cmd := fmt.Sprintf("set server %s/%s weight %d", backend, server, weight)
-		execCommand(cfg, cmd)
-		return fetchStats(cfg)
+		return commandResultMsg{command: cmd, reply: execAction(cfg, cmd)}
 	}
 }
 
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+)
+
+// auditEntry is one line of the audit log, recording a command sent to
+// HAProxy from a server action.
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Instance string    `json:"instance"`
+	Command  string    `json:"command"`
+	Reply    string    `json:"reply"`
+	Result   string    `json:"result"`
+}
+
+type auditMsg []auditEntry
+
+// Audit results. HAProxy answers most successful commands with an empty
+// reply, so any text it sends back is kept as "reply" for the operator to
+// judge rather than guessed to be an error.
+const (
+	auditResultOK    = "ok"
+	auditResultReply = "reply"
+	auditResultError = "error"
+)
+
+// execAction sends a mutating command to HAProxy and records it in the
+// audit log. Nothing is sent in read-only mode.
+func execAction(cfg Config, cmd string) string {
+	return execAudited(cfg, cmd, cmd)
+}
+
+// execPayloadAction sends a command followed by a multi-line payload, such
+// as a certificate for "set ssl cert". The payload is not written to the
+// audit log since it may hold private keys.
+func execPayloadAction(cfg Config, cmd, payload string) string {
+	full := cmd + " <<\n" + strings.TrimRight(payload, "\n") + "\n"
+	return execAudited(cfg, full, cmd+" <<[payload]")
+}
+
+// execAudited sends cmd and records it in the audit log as logged.
+func execAudited(cfg Config, cmd, logged string) string {
+	if cfg.readOnly {
+		return "Error: read-only mode"
+	}
+
+	reply := execCommand(cfg, cmd)
+
+	entry := auditEntry{
+		Time:     time.Now(),
+		User:     currentUser(),
+		Instance: cfg.socketPath,
+		Command:  logged,
+		Reply:    strings.TrimSpace(reply),
+		Result:   auditResult(reply),
+	}
+	if err := appendAuditEntry(entry); err != nil {
+		log.Printf("Failed to write audit log: %v", err)
+	}
+
+	return reply
+}
+
+func auditResult(reply string) string {
+	reply = strings.TrimSpace(reply)
+	switch {
+	case reply == "":
+		return auditResultOK
+	case strings.HasPrefix(reply, "Error:"):
+		// execCommand failed to reach the socket
+		return auditResultError
+	default:
+		return auditResultReply
+	}
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// appendAuditEntry adds entry as a JSON line to the audit log.
+func appendAuditEntry(entry auditEntry) error {
+	auditPath := getAuditLogPath()
+
+	if err := os.MkdirAll(filepath.Dir(auditPath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// loadAuditEntries reads the audit log, skipping lines that fail to parse.
+// A missing log yields no entries.
+func loadAuditEntries() ([]auditEntry, error) {
+	f, err := os.Open(getAuditLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+func fetchAudit() tea.Msg {
+	entries, err := loadAuditEntries()
+	if err != nil {
+		log.Printf("Failed to read audit log: %v", err)
+	}
+	return auditMsg(entries)
+}
+
+// auditRows converts entries to table rows, newest first.
+func auditRows(entries []auditEntry) []table.Row {
+	rows := make([]table.Row, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		rows = append(rows, table.Row{
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.User,
+			e.Instance,
+			e.Command,
+			e.Result,
+			strings.ReplaceAll(e.Reply, "\n", " "),
+		})
+	}
+	return rows
+}
+
+// getAuditLogPath returns the path to the audit log
+func getAuditLogPath() string {
+	// Try XDG_STATE_HOME first
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "lazyhap", "audit.jsonl")
+	}
+
+	// Fall back to ~/.local/state
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".local", "state", "lazyhap", "audit.jsonl")
+	}
+
+	// Last resort: current directory
+	return "lazyhap-audit.jsonl"
+}
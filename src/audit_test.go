@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuditResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		expected string
+	}{
+		{name: "empty reply", reply: "\n", expected: auditResultOK},
+		{name: "connection error", reply: "Error: dial unix: no such file", expected: auditResultError},
+		{name: "haproxy message", reply: "No such server.\n", expected: auditResultReply},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := auditResult(tt.reply)
+			if result != tt.expected {
+				t.Errorf("auditResult(%q) = %q; want %q", tt.reply, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAuditLogRoundTrip(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	entries, err := loadAuditEntries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("loadAuditEntries() on missing log = %v, %v; want no entries", entries, err)
+	}
+
+	first := auditEntry{Time: time.Unix(1000, 0), User: "alice", Instance: "/tmp/a.sock", Command: "disable server web/web-03", Result: auditResultOK}
+	second := auditEntry{Time: time.Unix(2000, 0), User: "bob", Instance: "/tmp/a.sock", Command: "enable server web/web-03", Result: auditResultOK}
+	for _, e := range []auditEntry{first, second} {
+		if err := appendAuditEntry(e); err != nil {
+			t.Fatalf("appendAuditEntry() error: %v", err)
+		}
+	}
+
+	entries, err = loadAuditEntries()
+	if err != nil {
+		t.Fatalf("loadAuditEntries() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("loadAuditEntries() returned %d entries; want 2", len(entries))
+	}
+
+	rows := auditRows(entries)
+	if rows[0][1] != "bob" || rows[1][1] != "alice" {
+		t.Errorf("auditRows() users = %q, %q; want newest first", rows[0][1], rows[1][1])
+	}
+	if rows[1][3] != first.Command {
+		t.Errorf("auditRows() command = %q; want %q", rows[1][3], first.Command)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/cafiles"
+)
+
+// caFileView is the state of the CA/CRL tab: the CA and CRL files with
+// their entries and, once one is opened, which one.
+type caFileView struct {
+	files        []cafiles.File
+	transactions []string // "<kind> <name>" of uncommitted "set ssl <kind>"
+	openKind     string
+	openName     string
+	pending      *caFileMsg // local file waiting for update confirmation
+}
+
+type caFilesMsg struct {
+	files        []cafiles.File
+	transactions []string
+}
+
+// fetchCAFiles lists the CA and CRL files and fetches the entries of each.
+func fetchCAFiles(cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		var msg caFilesMsg
+		for _, kind := range []string{cafiles.KindCA, cafiles.KindCRL} {
+			names, transactions := cafiles.ParseList(execCommand(cfg, "show ssl "+kind))
+			for _, name := range transactions {
+				msg.transactions = append(msg.transactions, kind+" "+name)
+			}
+			for _, name := range names {
+				msg.files = append(msg.files, cafiles.ParseFile(kind, name, execCommand(cfg, "show ssl "+kind+" "+name)))
+			}
+		}
+		return msg
+	}
+}
+
+// caFileMsg carries a local file read to update a CA or CRL file.
+type caFileMsg struct {
+	kind  string
+	name  string
+	path  string
+	data  []byte
+	local cafiles.File
+	err   error
+}
+
+func readCAFile(kind, name, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := caFileMsg{kind: kind, name: name, path: path}
+		msg.data, msg.err = os.ReadFile(path)
+		if msg.err == nil {
+			msg.local, msg.err = cafiles.ParsePEM(kind, path, msg.data)
+		}
+		return msg
+	}
+}
+
+// updateCAFile replaces the content of a CA or CRL file with "set ssl
+// <kind>" and "commit ssl <kind>", aborting the transaction if a step
+// fails.
+func updateCAFile(cfg Config, kind, name string, data []byte) tea.Cmd {
+	return func() tea.Msg {
+		label := "update " + kind + " " + name
+		fail := func(step, reply string) tea.Msg {
+			execAction(cfg, fmt.Sprintf("abort ssl %s %s", kind, name))
+			return commandResultMsg{command: label, reply: fmt.Sprintf("%s ssl %s failed: %s, transaction aborted", step, kind, firstLine(reply))}
+		}
+		if reply := execPayloadAction(cfg, fmt.Sprintf("set ssl %s %s", kind, name), string(data)); !transactionSucceeded("set", reply) {
+			return fail("set", reply)
+		}
+		if reply := execAction(cfg, fmt.Sprintf("commit ssl %s %s", kind, name)); !transactionSucceeded("commit", reply) {
+			return fail("commit", reply)
+		}
+		return commandResultMsg{command: label, reply: "Updated " + kind + " " + name}
+	}
+}
+
+func (m model) refreshCAFiles() tea.Cmd {
+	return fetchCAFiles(m.config)
+}
+
+// openCAFile returns the opened file, if it is still loaded.
+func (v caFileView) openCAFile() (cafiles.File, bool) {
+	for _, f := range v.files {
+		if f.Kind == v.openKind && f.Name == v.openName {
+			return f, true
+		}
+	}
+	return cafiles.File{}, false
+}
+
+// showCAFiles sets up the table for the list or the opened file.
+func (m *model) showCAFiles() {
+	if m.caFiles.openName != "" {
+		m.table = cafiles.InitializeEntriesTable(m.caFiles.openKind)
+	} else {
+		m.table = cafiles.InitializeListTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+func (m model) caFileRows() []table.Row {
+	if m.caFiles.openName == "" {
+		return cafiles.ListRows(m.caFiles.files, time.Now())
+	}
+	f, _ := m.caFiles.openCAFile()
+	return cafiles.EntryRows(f, time.Now())
+}
+
+// staleCAFiles counts the CRLs past their next update and the CA files
+// holding an expired certificate.
+func (m model) staleCAFiles() int {
+	count := 0
+	now := time.Now()
+	for _, f := range m.caFiles.files {
+		if f.Stale(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// selectedCAFile returns the kind and name of the opened or selected file.
+func (m model) selectedCAFile() (kind, name string) {
+	if m.caFiles.openName != "" {
+		return m.caFiles.openKind, m.caFiles.openName
+	}
+	row := m.table.SelectedRow()
+	if len(row) < 2 {
+		return "", ""
+	}
+	kind = cafiles.KindCA
+	if row[0] == "CRL" {
+		kind = cafiles.KindCRL
+	}
+	return kind, row[1]
+}
+
+// handleCAFile asks to update a CA or CRL file with a local file.
+func (m *model) handleCAFile(msg caFileMsg) {
+	if msg.err != nil {
+		m.message = "Cannot load " + msg.path + ": " + msg.err.Error()
+		return
+	}
+	m.caFiles.pending = &msg
+	m.confirmMode = true
+	m.confirmAction = "ca-file update"
+}
+
+// updatePrompt describes the pending update of a CA or CRL file.
+func (v caFileView) updatePrompt() string {
+	p := v.pending
+	noun := "certificate(s)"
+	if p.kind == cafiles.KindCRL {
+		noun = "CRL(s)"
+	}
+	prompt := fmt.Sprintf("Update %s %s with %d %s from %s", p.kind, p.name, len(p.local.Entries), noun, p.path)
+	if p.local.Stale(time.Now()) {
+		if p.kind == cafiles.KindCRL {
+			prompt += " (next update already past)"
+		} else {
+			prompt += " (holds an expired certificate)"
+		}
+	}
+	return prompt + "? (y/n)"
+}
+
+// updateCAFileKeys handles the keys of the CA/CRL tab. handled is false for
+// keys left to the common handling.
+func (m *model) updateCAFileKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	switch msg.String() {
+	case "enter":
+		if m.caFiles.openName != "" {
+			return nil, false
+		}
+		kind, name := m.selectedCAFile()
+		if name == "" {
+			return nil, true
+		}
+		m.caFiles.openKind, m.caFiles.openName = kind, name
+		m.filterInput = ""
+		m.showCAFiles()
+		return nil, true
+	case "esc", "backspace":
+		if m.caFiles.openName == "" {
+			return nil, false
+		}
+		m.caFiles.openName = ""
+		m.filterInput = ""
+		m.showCAFiles()
+		return nil, true
+	case "U":
+		kind, name := m.selectedCAFile()
+		if name == "" {
+			return nil, true
+		}
+		m.startTextInput(textAction{
+			label:  "Update from PEM file",
+			target: kind + " " + name,
+			next: func(m *model, path string) tea.Cmd {
+				return readCAFile(kind, name, path)
+			},
+		}, name)
+		return nil, true
+	case "C", "A":
+		if len(m.caFiles.transactions) == 0 {
+			return nil, true
+		}
+		verb := "commit"
+		if msg.String() == "A" {
+			verb = "abort"
+		}
+		m.askConfirmCommand(verb + " ssl " + m.caFiles.transactions[0])
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/certs"
+)
+
+// certList is the state of the Certs tab.
+type certList struct {
+	certs        []certs.Cert
+	transactions []string // certificates with an uncommitted "set ssl cert"
+	sort         tableSort
+	replacement  *certs.Replacement
+	pem          []byte // file content of the replacement
+	ocsp         []certs.OCSPResponse
+	showOCSP     bool
+}
+
+type certsMsg struct {
+	certs        []certs.Cert
+	transactions []string
+	periodic     bool // from the refresh loop, which schedules the next one
+}
+
+// certsTickMsg triggers the periodic refresh of the certificates.
+type certsTickMsg struct{}
+
+// fetchCerts lists the loaded certificates and fetches the details of each.
+// Unless detailed, the certificates already in known are reused as they
+// are, so keeping the tab bar badge current from other tabs costs a single
+// "show ssl cert" rather than one command per certificate.
+func fetchCerts(cfg Config, known []certs.Cert, detailed, periodic bool) tea.Msg {
+	names, transactions := certs.ParseList(execCommand(cfg, "show ssl cert"))
+	msg := certsMsg{transactions: transactions, periodic: periodic}
+	cached := map[string]certs.Cert{}
+	if !detailed {
+		for _, c := range known {
+			cached[c.Name] = c
+		}
+	}
+	for _, name := range names {
+		if c, ok := cached[name]; ok {
+			msg.certs = append(msg.certs, c)
+			continue
+		}
+		c := certs.ParseCert(name, execCommand(cfg, "show ssl cert "+name))
+		c.Disk = readDiskCert(cfg, name)
+		msg.certs = append(msg.certs, c)
+	}
+	return msg
+}
+
+// diskCertCache keeps the fingerprinted PEM files by path, so a refresh
+// only re-reads the files that changed since.
+var diskCertCache = struct {
+	sync.Mutex
+	entries map[string]diskCertEntry
+}{entries: map[string]diskCertEntry{}}
+
+type diskCertEntry struct {
+	modTime time.Time
+	size    int64
+	file    certs.DiskFile
+}
+
+// readDiskCert fingerprints the local PEM file of certificate name: the
+// file configured in cert_files, or the name itself. It returns nil when
+// there is no such file, as when lazyhap runs on another host.
+func readDiskCert(cfg Config, name string) *certs.DiskFile {
+	path := name
+	if p, ok := cfg.certFiles[name]; ok {
+		path = p
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return &certs.DiskFile{Path: path, Err: err.Error()}
+	}
+
+	diskCertCache.Lock()
+	entry, ok := diskCertCache.entries[path]
+	diskCertCache.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		file := entry.file
+		return &file
+	}
+
+	file := parseDiskCert(path)
+	diskCertCache.Lock()
+	diskCertCache.entries[path] = diskCertEntry{modTime: info.ModTime(), size: info.Size(), file: file}
+	diskCertCache.Unlock()
+	return &file
+}
+
+func parseDiskCert(path string) certs.DiskFile {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return certs.DiskFile{Path: path, Err: err.Error()}
+	}
+	c, _, err := certs.ParsePEM(path, data)
+	if err != nil {
+		return certs.DiskFile{Path: path, Err: err.Error()}
+	}
+	return certs.DiskFile{Path: path, Fingerprint: c.Fingerprint}
+}
+
+// driftedCerts counts the certificates whose file on disk differs from the
+// loaded one.
+func (m model) driftedCerts() int {
+	count := 0
+	for _, c := range m.certList.certs {
+		if c.Drifted() {
+			count++
+		}
+	}
+	return count
+}
+
+type ocspMsg []certs.OCSPResponse
+
+// fetchOCSP lists the stapled OCSP responses and fetches the details of
+// each.
+func fetchOCSP(cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		responses := certs.ParseOCSPList(execCommand(cfg, "show ssl ocsp-response"))
+		for i, r := range responses {
+			responses[i] = certs.ParseOCSPResponse(r, execCommand(cfg, "show ssl ocsp-response "+r.ID))
+		}
+		return ocspMsg(responses)
+	}
+}
+
+func (m model) refreshCerts() tea.Cmd {
+	if m.certList.showOCSP {
+		return fetchOCSP(m.config)
+	}
+	return m.fetchCertList(false)
+}
+
+// fetchCertList fetches the certificates, with the details of each only
+// while the Certs tab is shown.
+func (m model) fetchCertList(periodic bool) tea.Cmd {
+	cfg, known, detailed := m.config, m.certList.certs, m.activeTab == certsTab
+	return func() tea.Msg { return fetchCerts(cfg, known, detailed, periodic) }
+}
+
+// showCerts sets up the table for the certificates or the OCSP responses.
+func (m *model) showCerts() {
+	if m.certList.showOCSP {
+		m.table = certs.InitializeOCSPTable()
+	} else {
+		m.table = certs.InitializeTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+// certRows returns the certificates, or the OCSP responses, in the selected
+// sort order.
+func (m model) certRows() []table.Row {
+	if m.certList.showOCSP {
+		return m.certList.sort.apply(certs.OCSPRows(m.certList.ocsp, time.Now()))
+	}
+	rows := certs.Rows(m.certList.certs, time.Now(), m.config.certWarnDays)
+	return m.certList.sort.apply(rows)
+}
+
+// staleOCSP counts the OCSP responses past their next update.
+func (m model) staleOCSP() int {
+	count := 0
+	now := time.Now()
+	for _, r := range m.certList.ocsp {
+		if r.Stale(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// expiringCerts counts the certificates expiring within the warning
+// threshold, expired ones included.
+func (m model) expiringCerts() int {
+	count := 0
+	now := time.Now()
+	for _, c := range m.certList.certs {
+		if c.Expiring(now, m.config.certWarnDays) {
+			count++
+		}
+	}
+	return count
+}
+
+// certFileMsg carries a local PEM file read to replace a loaded
+// certificate, or to create a new one when create is set.
+type certFileMsg struct {
+	name   string
+	file   string
+	data   []byte
+	cert   certs.Cert
+	hasKey bool
+	create bool
+	err    error
+}
+
+// loadCertFile reads the PEM file at path to replace certificate name.
+func loadCertFile(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		return readCertFile(name, path)
+	}
+}
+
+// readNewCertFile reads the PEM file at path to create certificate name.
+func readNewCertFile(name, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := readCertFile(name, path)
+		msg.create = true
+		return msg
+	}
+}
+
+// readCertFile reads and parses the PEM file at path.
+func readCertFile(name, path string) certFileMsg {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return certFileMsg{name: name, file: path, err: err}
+	}
+	cert, hasKey, err := certs.ParsePEM(path, data)
+	return certFileMsg{name: name, file: path, data: data, cert: cert, hasKey: hasKey, err: err}
+}
+
+// certTransactionMsg carries the reply to a step of a certificate
+// replacement: "set" or "commit".
+type certTransactionMsg struct {
+	name  string
+	step  string
+	reply string
+}
+
+// setCert uploads a certificate into a new transaction.
+func setCert(cfg Config, name string, data []byte) tea.Cmd {
+	return func() tea.Msg {
+		reply := execPayloadAction(cfg, "set ssl cert "+name, string(data))
+		return certTransactionMsg{name: name, step: "set", reply: reply}
+	}
+}
+
+// commitCert commits the transaction of a certificate.
+func commitCert(cfg Config, name string) tea.Cmd {
+	return func() tea.Msg {
+		reply := execAction(cfg, "commit ssl cert "+name)
+		return certTransactionMsg{name: name, step: "commit", reply: reply}
+	}
+}
+
+// abortCert aborts the transaction of a certificate after a failed step.
+func abortCert(cfg Config, name, failure string) tea.Cmd {
+	return func() tea.Msg {
+		execAction(cfg, "abort ssl cert "+name)
+		return commandResultMsg{command: "replace " + name, reply: failure + ", transaction aborted"}
+	}
+}
+
+// transactionSucceeded reports whether HAProxy accepted a step of a
+// certificate, CA or CRL file transaction.
+func transactionSucceeded(step, reply string) bool {
+	if step == "set" {
+		reply = strings.ToLower(reply)
+		return strings.Contains(reply, "transaction created") || strings.Contains(reply, "transaction updated")
+	}
+	return strings.Contains(reply, "Success!")
+}
+
+// handleCertTransaction moves a replacement on to its next step, aborting
+// the transaction when a step fails.
+func (m *model) handleCertTransaction(msg certTransactionMsg) tea.Cmd {
+	r := m.certList.replacement
+	if r == nil || r.Current.Name != msg.name {
+		return nil
+	}
+	if !transactionSucceeded(msg.step, msg.reply) {
+		m.closeReplacement()
+		failure := fmt.Sprintf("%s ssl cert failed: %s", msg.step, firstLine(msg.reply))
+		return abortCert(m.config, msg.name, failure)
+	}
+	if msg.step == "set" {
+		r.Stage = "commit ssl cert"
+		return commitCert(m.config, msg.name)
+	}
+	m.closeReplacement()
+	return func() tea.Msg {
+		return commandResultMsg{command: "replace " + msg.name, reply: "Replaced " + msg.name}
+	}
+}
+
+// closeReplacement returns from a replacement preview to the list.
+func (m *model) closeReplacement() {
+	m.certList.replacement = nil
+	m.certList.pem = nil
+	if m.activeTab == certsTab {
+		m.switchTab(certsTab)
+	}
+}
+
+// firstLine returns the first non-empty line of a reply.
+func firstLine(reply string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(reply), "\n")
+	return line
+}
+
+// updateCertKeys handles the keys of the Certs tab. handled is false for
+// keys left to the common handling.
+func (m *model) updateCertKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	if r := m.certList.replacement; r != nil {
+		switch msg.String() {
+		case "enter":
+			if r.Stage != "" {
+				return nil, true
+			}
+			m.confirmMode = true
+			m.confirmAction = "cert replace"
+			return nil, true
+		case "esc", "backspace":
+			if r.Stage == "" {
+				m.closeReplacement()
+			}
+			return nil, true
+		}
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "s":
+		m.certList.sort.cycle(len(m.table.Columns()))
+		m.applyFilter()
+		return nil, true
+	case "o":
+		m.certList.showOCSP = !m.certList.showOCSP
+		m.certList.sort = tableSort{column: -1}
+		m.filterInput = ""
+		m.showCerts()
+		return m.refreshCerts(), true
+	}
+
+	if m.certList.showOCSP {
+		if msg.String() != "u" {
+			return nil, false
+		}
+		row := m.table.SelectedRow()
+		if len(row) == 0 {
+			return nil, true
+		}
+		path := m.ocspPath(row[0])
+		if path == "" {
+			m.message = "HAProxy does not report the certificate path of this response (needs 2.8 or later)"
+			return nil, true
+		}
+		m.message = "Updating OCSP response of " + path + "..."
+		return runServerCommand(m.config, "update ssl ocsp-response "+path), true
+	}
+
+	switch msg.String() {
+	case "U":
+		name := m.selectedCert()
+		if name == "" {
+			return nil, true
+		}
+		m.startTextInput(textAction{
+			label:  "Replace with PEM file",
+			target: name,
+			next: func(m *model, path string) tea.Cmd {
+				return loadCertFile(name, path)
+			},
+		}, "")
+		return nil, true
+	case "D":
+		c, ok := m.selectedCertDetails()
+		if !ok || !c.Drifted() {
+			m.message = "The selected certificate matches its file on disk"
+			return nil, true
+		}
+		return loadCertFile(c.Name, c.Disk.Path), true
+	case "C", "A":
+		// HAProxy allows a single certificate transaction at a time
+		if len(m.certList.transactions) == 0 {
+			return nil, true
+		}
+		verb := "commit"
+		if msg.String() == "A" {
+			verb = "abort"
+		}
+		m.askConfirmCommand(verb + " ssl cert " + m.certList.transactions[0])
+		return nil, true
+	}
+	return nil, false
+}
+
+// ocspPath returns the certificate path of the OCSP response shown as
+// name, or "" when HAProxy did not report it.
+func (m model) ocspPath(name string) string {
+	for _, r := range m.certList.ocsp {
+		if r.Path != "" && r.Path == name {
+			return r.Path
+		}
+	}
+	return ""
+}
+
+// selectedCertDetails returns the selected certificate.
+func (m model) selectedCertDetails() (certs.Cert, bool) {
+	name := m.selectedCert()
+	for _, c := range m.certList.certs {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return certs.Cert{}, false
+}
+
+// selectedCert returns the name of the selected certificate.
+func (m model) selectedCert() string {
+	if row := m.table.SelectedRow(); len(row) > 0 {
+		return row[0]
+	}
+	return ""
+}
+
+// handleCertFile shows the comparison of a loaded PEM file with the
+// certificate it replaces.
+func (m *model) handleCertFile(msg certFileMsg) {
+	if msg.err != nil {
+		m.message = "Cannot load " + msg.file + ": " + msg.err.Error()
+		return
+	}
+	for _, c := range m.certList.certs {
+		if c.Name == msg.name {
+			m.certList.replacement = &certs.Replacement{File: msg.file, Current: c, Next: msg.cert, HasKey: msg.hasKey}
+			m.certList.pem = msg.data
+			m.viewport.GotoTop()
+			return
+		}
+	}
+	m.message = "Certificate " + msg.name + " is no longer loaded"
+}
+
+// certsBadge warns in the tab bar about certificates expiring soon.
+func (m model) certsBadge() string {
+	count := m.expiringCerts()
+	if count == 0 {
+		return ""
+	}
+	return " ⚠" + strconv.Itoa(count)
+}
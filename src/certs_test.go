@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/knowald/lazyhap/src/views/certs"
+)
+
+func TestReadDiskCert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(0, 3, 0),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	renewed := filepath.Join(dir, "renewed.pem")
+	if err := os.WriteFile(renewed, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "broken.pem")
+	if err := os.WriteFile(broken, []byte("not a certificate\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{certFiles: map[string]string{"/etc/haproxy/site.pem": renewed}}
+
+	disk := readDiskCert(cfg, "/etc/haproxy/site.pem")
+	if disk == nil || disk.Path != renewed || disk.Err != "" || len(disk.Fingerprint) != 40 {
+		t.Fatalf("readDiskCert() of a mapped file = %+v", disk)
+	}
+	loaded := certs.Cert{Name: "/etc/haproxy/site.pem", Fingerprint: "C2C0D2A5E94D9F7B7B8F0B9C0E7E6E5A4D3C2B1A", Disk: disk}
+	if !loaded.Drifted() {
+		t.Errorf("Drifted() with a renewed file = false; want true")
+	}
+	loaded.Fingerprint = disk.Fingerprint
+	if loaded.Drifted() {
+		t.Errorf("Drifted() with the same file = true; want false")
+	}
+
+	if disk := readDiskCert(cfg, broken); disk == nil || disk.Err == "" {
+		t.Errorf("readDiskCert() of an invalid file = %+v; want an error", disk)
+	}
+	if disk := readDiskCert(cfg, filepath.Join(dir, "missing.pem")); disk != nil {
+		t.Errorf("readDiskCert() of a missing file = %+v; want nil", disk)
+	}
+}
+
+func TestReadDiskCertCache(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(2), NotBefore: time.Now(), NotAfter: time.Now().AddDate(0, 3, 0)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	path := filepath.Join(t.TempDir(), "site.pem")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := readDiskCert(Config{}, path)
+
+	// Same size and modification time: the file isn't read again
+	garbage := make([]byte, len(data))
+	if err := os.WriteFile(path, garbage, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if cached := readDiskCert(Config{}, path); cached.Fingerprint != first.Fingerprint || cached.Err != "" {
+		t.Errorf("readDiskCert() of an unchanged file = %+v; want the cached %+v", cached, first)
+	}
+
+	// Another size: the file is parsed again
+	if err := os.WriteFile(path, []byte("not a certificate\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if changed := readDiskCert(Config{}, path); changed.Err == "" {
+		t.Errorf("readDiskCert() of a changed file = %+v; want an error", changed)
+	}
+}
+
+// fakeSocket answers each connection's command with replies[command] and
+// records the commands received.
+func fakeSocket(t *testing.T, replies map[string]string) (path string, commands chan string) {
+	path = filepath.Join(t.TempDir(), "haproxy.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	commands = make(chan string, 100)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			cmd, _ := bufio.NewReader(conn).ReadString('\n')
+			cmd = strings.TrimSpace(cmd)
+			commands <- cmd
+			conn.Write([]byte(replies[cmd]))
+			conn.Close()
+		}
+	}()
+	return path, commands
+}
+
+func TestFetchCertsReusesKnownDetails(t *testing.T) {
+	socket, commands := fakeSocket(t, map[string]string{
+		"show ssl cert": "# filename\n/etc/haproxy/a.pem\n/etc/haproxy/b.pem\n",
+	})
+	cfg := Config{socketPath: socket}
+	known := []certs.Cert{{Name: "/etc/haproxy/a.pem", Serial: "01"}}
+
+	msg := fetchCerts(cfg, known, false, true).(certsMsg)
+	close(commands)
+	var sent []string
+	for cmd := range commands {
+		sent = append(sent, cmd)
+	}
+
+	// Only the new certificate is described
+	if len(sent) != 2 || sent[1] != "show ssl cert /etc/haproxy/b.pem" {
+		t.Errorf("commands = %q", sent)
+	}
+	if len(msg.certs) != 2 || msg.certs[0].Serial != "01" {
+		t.Errorf("certs = %+v", msg.certs)
+	}
+}
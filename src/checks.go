@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkAction is one entry of the checks submenu on server rows.
+type checkAction struct {
+	key   string
+	label string
+	cmd   string // format string, receives backend, server (and the input value)
+	input string // prompt for the value to send, empty when none is needed
+}
+
+var checkActions = []checkAction{
+	{key: "h", label: "enable health", cmd: "enable health %s/%s"},
+	{key: "H", label: "disable health", cmd: "disable health %s/%s"},
+	{key: "u", label: "health up", cmd: "set server %s/%s health up"},
+	{key: "d", label: "health down", cmd: "set server %s/%s health down"},
+	{key: "s", label: "health stopping", cmd: "set server %s/%s health stopping"},
+	{key: "c", label: "check-addr", cmd: "set server %s/%s check-addr %s", input: "Check address"},
+	{key: "p", label: "check-port", cmd: "set server %s/%s check-port %s", input: "Check port"},
+	{key: "a", label: "enable agent", cmd: "enable agent %s/%s"},
+	{key: "A", label: "disable agent", cmd: "disable agent %s/%s"},
+	{key: "U", label: "agent up", cmd: "set server %s/%s agent up"},
+	{key: "D", label: "agent down", cmd: "set server %s/%s agent down"},
+	{key: "o", label: "agent-addr", cmd: "set server %s/%s agent-addr %s", input: "Agent address"},
+	{key: "t", label: "agent-port", cmd: "set server %s/%s agent-port %s", input: "Agent port"},
+	{key: "m", label: "agent-send", cmd: "set server %s/%s agent-send %s", input: "Agent send string"},
+}
+
+// findCheckAction returns the checks submenu entry bound to key.
+func findCheckAction(key string) (checkAction, bool) {
+	for _, a := range checkActions {
+		if a.key == key {
+			return a, true
+		}
+	}
+	return checkAction{}, false
+}
+
+// command builds the runtime API command for this action.
+func (a checkAction) command(backend, server, value string) string {
+	if a.input != "" {
+		return fmt.Sprintf(a.cmd, backend, server, value)
+	}
+	return fmt.Sprintf(a.cmd, backend, server)
+}
+
+// checksMenuHint renders the submenu as a single "key: label" hint line.
+func checksMenuHint() string {
+	parts := make([]string, 0, len(checkActions)+1)
+	for _, a := range checkActions {
+		parts = append(parts, a.key+": "+a.label)
+	}
+	parts = append(parts, "esc: cancel")
+	return strings.Join(parts, "  ")
+}
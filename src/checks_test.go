@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestChecksSubmenuKeys(t *testing.T) {
+	var m tea.Model = model{checksMode: true, confirmBackend: "be", confirmServer: "s1"}
+
+	// Unknown keys leave the submenu open
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if !m.(model).checksMode {
+		t.Fatal("submenu closed on an unknown key")
+	}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	got := m.(model)
+	if got.checksMode || !got.confirmMode || got.confirmCommand != "enable health be/s1" {
+		t.Errorf("after h: checksMode %v, confirm %v %q", got.checksMode, got.confirmMode, got.confirmCommand)
+	}
+}
@@ -3,4 +3,11 @@ package main
 // Config holds configuration for connecting to HAProxy
 type Config struct {
 	socketPath string
+	readOnly   bool
+	rolling    rollingConfig
+	// Certificates expiring within this many days are flagged
+	certWarnDays int
+	// Local PEM file of a certificate, by the name HAProxy reports; the
+	// name itself is used for certificates not listed
+	certFiles map[string]string
 }
@@ -9,8 +9,22 @@ import (
 
 // AppConfig represents the application configuration
 type AppConfig struct {
-	SocketPath      string        `json:"socket_path"`
-	RefreshInterval time.Duration `json:"refresh_interval_ms"` // in milliseconds
+	SocketPath            string        `json:"socket_path"`
+	RefreshInterval       time.Duration `json:"refresh_interval_ms"` // in milliseconds
+	ReadOnly              bool          `json:"read_only"`
+	Rolling               RollingConfig `json:"rolling"`
+	CertExpiryWarningDays int           `json:"cert_expiry_warning_days"`
+	// Local PEM file of each certificate, when it differs from the name
+	// HAProxy reports
+	CertFiles map[string]string `json:"cert_files"`
+}
+
+// RollingConfig configures rolling operations
+type RollingConfig struct {
+	Steps         []string      `json:"steps"`
+	DrainTimeout  time.Duration `json:"drain_timeout_s"`  // in seconds
+	HealthTimeout time.Duration `json:"health_timeout_s"` // in seconds
+	RampDuration  time.Duration `json:"ramp_duration_s"`  // in seconds
 }
 
 // DefaultConfig returns the default configuration
@@ -18,6 +32,12 @@ func DefaultConfig() AppConfig {
 	return AppConfig{
 		SocketPath:      DefaultSocketPath,
 		RefreshInterval: RefreshInterval,
+		Rolling: RollingConfig{
+			DrainTimeout:  DefaultDrainTimeout,
+			HealthTimeout: DefaultHealthTimeout,
+			RampDuration:  DefaultRollingRamp,
+		},
+		CertExpiryWarningDays: DefaultCertExpiryWarningDays,
 	}
 }
 
@@ -37,6 +57,15 @@ func LoadConfig() AppConfig {
 	var fileConfig struct {
 		SocketPath         string `json:"socket_path"`
 		RefreshIntervalMs  int    `json:"refresh_interval_ms"`
+		ReadOnly           bool   `json:"read_only"`
+		Rolling            struct {
+			Steps          []string `json:"steps"`
+			DrainTimeoutS  int      `json:"drain_timeout_s"`
+			HealthTimeoutS int      `json:"health_timeout_s"`
+			RampDurationS  *int     `json:"ramp_duration_s"`
+		} `json:"rolling"`
+		CertExpiryWarningDays int               `json:"cert_expiry_warning_days"`
+		CertFiles             map[string]string `json:"cert_files"`
 	}
 
 	if err := json.Unmarshal(data, &fileConfig); err != nil {
@@ -51,6 +80,22 @@ func LoadConfig() AppConfig {
 	if fileConfig.RefreshIntervalMs > 0 {
 		config.RefreshInterval = time.Duration(fileConfig.RefreshIntervalMs) * time.Millisecond
 	}
+	config.ReadOnly = fileConfig.ReadOnly
+	config.Rolling.Steps = fileConfig.Rolling.Steps
+	if fileConfig.Rolling.DrainTimeoutS > 0 {
+		config.Rolling.DrainTimeout = time.Duration(fileConfig.Rolling.DrainTimeoutS) * time.Second
+	}
+	if fileConfig.Rolling.HealthTimeoutS > 0 {
+		config.Rolling.HealthTimeout = time.Duration(fileConfig.Rolling.HealthTimeoutS) * time.Second
+	}
+	// 0 restores the weight in one step
+	if d := fileConfig.Rolling.RampDurationS; d != nil && *d >= 0 {
+		config.Rolling.RampDuration = time.Duration(*d) * time.Second
+	}
+	if fileConfig.CertExpiryWarningDays > 0 {
+		config.CertExpiryWarningDays = fileConfig.CertExpiryWarningDays
+	}
+	config.CertFiles = fileConfig.CertFiles
 
 	return config
 }
@@ -69,10 +114,26 @@ func SaveConfig(config AppConfig) error {
 	fileConfig := struct {
 		SocketPath        string `json:"socket_path"`
 		RefreshIntervalMs int    `json:"refresh_interval_ms"`
+		ReadOnly          bool   `json:"read_only"`
+		Rolling           struct {
+			Steps          []string `json:"steps,omitempty"`
+			DrainTimeoutS  int      `json:"drain_timeout_s"`
+			HealthTimeoutS int      `json:"health_timeout_s"`
+			RampDurationS  int      `json:"ramp_duration_s"`
+		} `json:"rolling"`
+		CertExpiryWarningDays int               `json:"cert_expiry_warning_days"`
+		CertFiles             map[string]string `json:"cert_files,omitempty"`
 	}{
-		SocketPath:        config.SocketPath,
-		RefreshIntervalMs: int(config.RefreshInterval / time.Millisecond),
+		SocketPath:            config.SocketPath,
+		RefreshIntervalMs:     int(config.RefreshInterval / time.Millisecond),
+		ReadOnly:              config.ReadOnly,
+		CertExpiryWarningDays: config.CertExpiryWarningDays,
+		CertFiles:             config.CertFiles,
 	}
+	fileConfig.Rolling.Steps = config.Rolling.Steps
+	fileConfig.Rolling.DrainTimeoutS = int(config.Rolling.DrainTimeout / time.Second)
+	fileConfig.Rolling.HealthTimeoutS = int(config.Rolling.HealthTimeout / time.Second)
+	fileConfig.Rolling.RampDurationS = int(config.Rolling.RampDuration / time.Second)
 
 	// Marshal to JSON
 	data, err := json.MarshalIndent(fileConfig, "", "  ")
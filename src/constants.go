@@ -16,9 +16,46 @@ const (
 	MessageDisplayTime   = 2 * time.Second
 	RetryConnectionDelay = 5 * time.Second
 
+	// Rolling operations
+	RollingPollInterval  = 2 * time.Second
+	DefaultDrainTimeout  = 5 * time.Minute
+	DefaultHealthTimeout = 2 * time.Minute
+	DefaultRollingRamp   = time.Minute
+	MaxRollingLogLines   = 12
+
 	// HAProxy stats format
 	MinStatsFields = 80
 
 	// Server weight
 	DefaultServerWeight = 100
+	MaxServerWeight     = 256
+
+	// Weight ramps
+	DefaultRampSteps       = 10
+	MaxRampSteps           = 100
+	MaxRampDurationSeconds = 3600
+
+	// Number of actions kept on the undo stack
+	MaxUndoEntries = 50
+
+	// Upper bounds for maxconn and rate-limit inputs
+	MaxConnLimit = 10000000
+	MaxRateLimit = 10000000
+
+	// Days before expiry at which certificates are flagged by default
+	DefaultCertExpiryWarningDays = 30
+
+	// Rows shown per group and longest-lived sessions in session analytics
+	MaxAnalyticsRows = 10
+
+	// Upper bound for stick table counters set by hand (32-bit)
+	MaxStickTableData = 4294967295
+
+	// Refreshes over which a pool must keep growing to be flagged as a
+	// potential leak
+	PoolLeakSamples = 30
+
+	// Lines kept when following an event ring, and read per update
+	MaxEventLines    = 5000
+	EventStreamBatch = 256
 )
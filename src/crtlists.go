@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/crtlists"
+)
+
+// crtListView is the state of the Crt-lists tab: the crt-list files and,
+// once one is opened, its entries.
+type crtListView struct {
+	list     []table.Row
+	entries  []table.Row
+	openFile string
+	newCert  *certFileMsg // certificate waiting for "new ssl cert" confirmation
+	lastCert string       // last certificate created, offered when adding an entry
+	add      *crtListAdd  // entry waiting for "add ssl crt-list" confirmation
+}
+
+// crtListAdd is an entry to add to a crt-list file: a certificate and its
+// optional "[ssl options] sni filters".
+type crtListAdd struct {
+	file string
+	cert string
+	rest string
+}
+
+// command returns the "add ssl crt-list" command for the entry. The one-line
+// form only takes the certificate, so an entry with options or SNI filters
+// is sent as a payload line.
+func (a crtListAdd) command() (cmd, payload string) {
+	if a.rest == "" {
+		return "add ssl crt-list " + a.file + " " + a.cert, ""
+	}
+	return "add ssl crt-list " + a.file, a.cert + " " + a.rest
+}
+
+// addCrtListEntry sends the "add ssl crt-list" command for a.
+func addCrtListEntry(cfg Config, a crtListAdd) tea.Cmd {
+	return func() tea.Msg {
+		cmd, payload := a.command()
+		if payload == "" {
+			return commandResultMsg{command: cmd, reply: execAction(cfg, cmd)}
+		}
+		return commandResultMsg{command: cmd + " " + payload, reply: execPayloadAction(cfg, cmd, payload)}
+	}
+}
+
+type crtListMsg string
+
+type crtListEntriesMsg struct {
+	file   string
+	output string
+}
+
+func fetchCrtLists(cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		return crtListMsg(execCommand(cfg, "show ssl crt-list"))
+	}
+}
+
+func fetchCrtListEntries(cfg Config, file string) tea.Cmd {
+	return func() tea.Msg {
+		return crtListEntriesMsg{file: file, output: execCommand(cfg, "show ssl crt-list -n "+file)}
+	}
+}
+
+func (m model) refreshCrtLists() tea.Cmd {
+	if m.crtLists.openFile != "" {
+		return fetchCrtListEntries(m.config, m.crtLists.openFile)
+	}
+	return fetchCrtLists(m.config)
+}
+
+// showCrtLists sets up the table for the list or the opened crt-list.
+func (m *model) showCrtLists() {
+	if m.crtLists.openFile != "" {
+		m.table = crtlists.InitializeEntriesTable()
+	} else {
+		m.table = crtlists.InitializeListTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+func (m model) crtListRows() []table.Row {
+	if m.crtLists.openFile != "" {
+		return m.crtLists.entries
+	}
+	return m.crtLists.list
+}
+
+// createCert onboards a certificate at runtime: "new ssl cert" creates an
+// empty store, then "set ssl cert" and "commit ssl cert" fill it. A store
+// left behind by a failed step is removed.
+func createCert(cfg Config, name string, data []byte) tea.Cmd {
+	return func() tea.Msg {
+		label := "new ssl cert " + name
+		if reply := execAction(cfg, label); !strings.Contains(reply, "New empty") {
+			return commandResultMsg{command: label, reply: "new ssl cert failed: " + firstLine(reply)}
+		}
+		fail := func(step, reply string) tea.Msg {
+			execAction(cfg, "abort ssl cert "+name)
+			execAction(cfg, "del ssl cert "+name)
+			return commandResultMsg{command: label, reply: fmt.Sprintf("%s ssl cert failed: %s, certificate removed", step, firstLine(reply))}
+		}
+		if reply := execPayloadAction(cfg, "set ssl cert "+name, string(data)); !transactionSucceeded("set", reply) {
+			return fail("set", reply)
+		}
+		if reply := execAction(cfg, "commit ssl cert "+name); !transactionSucceeded("commit", reply) {
+			return fail("commit", reply)
+		}
+		return commandResultMsg{command: label, reply: "Created " + name + ", add it to a crt-list to serve it"}
+	}
+}
+
+// handleNewCertFile asks to create the certificate read from a local file.
+func (m *model) handleNewCertFile(msg certFileMsg) {
+	if msg.err != nil {
+		m.message = "Cannot load " + msg.file + ": " + msg.err.Error()
+		return
+	}
+	m.crtLists.newCert = &msg
+	m.confirmMode = true
+	m.confirmAction = "new cert"
+}
+
+// newCertPrompt describes the certificate about to be created.
+func (v crtListView) newCertPrompt() string {
+	c := v.newCert.cert
+	prompt := fmt.Sprintf("Create %s from %s (%s, SAN %s, expires %s)", v.newCert.name, v.newCert.file,
+		c.Subject, strings.Join(c.SAN, ", "), c.NotAfter.Format("2006-01-02"))
+	if !v.newCert.hasKey {
+		prompt += " without a private key"
+	}
+	return prompt + "? (y/n)"
+}
+
+// updateCrtListKeys handles the keys of the Crt-lists tab. handled is false
+// for keys left to the common handling.
+func (m *model) updateCrtListKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	row := m.table.SelectedRow()
+
+	if msg.String() == "n" {
+		m.startTextInput(textAction{
+			label: "New certificate name",
+			next: func(m *model, name string) tea.Cmd {
+				m.startTextInput(textAction{
+					label:  "PEM file",
+					target: name,
+					next: func(m *model, path string) tea.Cmd {
+						return readNewCertFile(name, path)
+					},
+				}, name)
+				return nil
+			},
+		}, "")
+		return nil, true
+	}
+
+	if m.crtLists.openFile == "" {
+		if msg.String() == "enter" && len(row) >= 1 {
+			m.crtLists.openFile = row[0]
+			m.crtLists.entries = nil
+			m.filterInput = ""
+			m.showCrtLists()
+			return m.refreshCrtLists(), true
+		}
+		return nil, false
+	}
+
+	file := m.crtLists.openFile
+	switch msg.String() {
+	case "esc", "backspace":
+		m.crtLists.openFile = ""
+		m.filterInput = ""
+		m.showCrtLists()
+		return m.refreshCrtLists(), true
+	case "a":
+		m.startTextInput(textAction{
+			label:  "Certificate",
+			target: file,
+			next: func(m *model, cert string) tea.Cmd {
+				m.startTextInput(textAction{
+					label:    "[SSL options] SNI filters (optional)",
+					target:   cert,
+					optional: true,
+					next: func(m *model, rest string) tea.Cmd {
+						m.crtLists.add = &crtListAdd{file: file, cert: cert, rest: strings.TrimSpace(rest)}
+						m.confirmMode = true
+						m.confirmAction = "crt-list add"
+						return nil
+					},
+				}, "")
+				return nil
+			},
+		}, m.crtLists.lastCert)
+		return nil, true
+	case "x":
+		if len(row) >= 2 {
+			entry := row[0]
+			if row[1] != "" {
+				entry += ":" + row[1]
+			}
+			m.askConfirmCommand(fmt.Sprintf("del ssl crt-list %s %s", file, entry))
+		}
+		return nil, true
+	}
+	return nil, false
+}
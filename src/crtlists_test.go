@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestCrtListAddCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		add         crtListAdd
+		wantCmd     string
+		wantPayload string
+	}{
+		{
+			name:    "certificate only",
+			add:     crtListAdd{file: "/etc/haproxy/crt.lst", cert: "/etc/haproxy/site.pem"},
+			wantCmd: "add ssl crt-list /etc/haproxy/crt.lst /etc/haproxy/site.pem",
+		},
+		{
+			name:        "options and SNI filters",
+			add:         crtListAdd{file: "/etc/haproxy/crt.lst", cert: "/etc/haproxy/site.pem", rest: "[alpn h2 ocsp-update on] site.com !www.site.com"},
+			wantCmd:     "add ssl crt-list /etc/haproxy/crt.lst",
+			wantPayload: "/etc/haproxy/site.pem [alpn h2 ocsp-update on] site.com !www.site.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, payload := tt.add.command()
+			if cmd != tt.wantCmd || payload != tt.wantPayload {
+				t.Errorf("command() = %q, %q; want %q, %q", cmd, payload, tt.wantCmd, tt.wantPayload)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	errorview "github.com/knowald/lazyhap/src/views/error"
+)
+
+// errorList is the state of the Errors tab: the captures from "show errors"
+// and the one opened for decoding, if any.
+type errorList struct {
+	captures []errorview.Capture
+	summary  string // "Total events captured on [...] : N"
+	open     bool
+	openTime string
+	openID   string // event number
+	hex      bool
+}
+
+// handleErrors stores freshly fetched captures.
+func (m *model) handleErrors(output string) {
+	m.errorList.captures = errorview.ParseCaptures(output)
+	m.errorList.summary = ""
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "Total events captured") {
+			m.errorList.summary = strings.TrimSpace(line)
+			break
+		}
+	}
+	if m.activeTab == errorTab && !m.errorList.open {
+		m.applyFilter()
+	}
+}
+
+// openCapture returns the capture opened for decoding. It may have left the
+// capture buffers, which only keep the last error of each proxy.
+func (m model) openCapture() (errorview.Capture, bool) {
+	e := m.errorList
+	if !e.open {
+		return errorview.Capture{}, false
+	}
+	for _, c := range e.captures {
+		if c.Time == e.openTime && c.Event == e.openID {
+			return c, true
+		}
+	}
+	return errorview.Capture{}, false
+}
+
+// updateErrorKeys handles the keys of the Errors tab. handled is false for
+// keys left to the common handling.
+func (m *model) updateErrorKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	e := &m.errorList
+
+	if e.open {
+		switch msg.String() {
+		case "esc", "backspace":
+			e.open = false
+			m.switchTab(errorTab)
+			return nil, true
+		case "x":
+			e.hex = !e.hex
+			return nil, true
+		}
+		return nil, false
+	}
+
+	if msg.String() == "enter" {
+		row := m.table.SelectedRow()
+		if len(row) >= 2 {
+			e.open = true
+			e.openTime, e.openID = row[0], row[1]
+			m.viewport.GotoTop()
+		}
+		return nil, true
+	}
+	return nil, false
+}
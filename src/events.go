@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/events"
+)
+
+// eventView is the state of the Events tab: the event sinks and, once one
+// is followed, its stream.
+type eventView struct {
+	rings  []events.Ring
+	stream *eventStream
+	seq    int // id of the last stream, to ignore lines of closed ones
+	buffer events.Buffer
+	paused bool
+	held   int    // lines received while paused
+	ended  string // why the stream stopped, if it did
+}
+
+// eventStream follows a ring with "show events <ring> -w" on its own
+// connection, which stays open until closed. owner is the tab whose
+// eventView the stream belongs to.
+type eventStream struct {
+	owner tab
+	id    int
+	ring  string
+	conn  net.Conn
+	lines chan string
+	done  chan struct{} // closed by close, so the reader stops sending
+}
+
+type eventStreamMsg struct {
+	owner  tab
+	stream *eventStream
+	err    error
+}
+
+type eventLinesMsg struct {
+	owner tab
+	id    int
+	lines []string
+}
+
+type eventStreamEndMsg struct {
+	owner tab
+	id    int
+}
+
+// openEventStream connects to the socket and follows ring, from its
+// current content or, with newOnly, from the next event.
+func openEventStream(cfg Config, owner tab, id int, ring string, newOnly bool) tea.Cmd {
+	return func() tea.Msg {
+		conn, err := net.Dial("unix", cfg.socketPath)
+		if err != nil {
+			return eventStreamMsg{owner: owner, err: err}
+		}
+		cmd := "show events " + ring + " -w"
+		if newOnly {
+			cmd += " -n"
+		}
+		if _, err := fmt.Fprintf(conn, "%s\n", cmd); err != nil {
+			conn.Close()
+			return eventStreamMsg{owner: owner, err: err}
+		}
+
+		s := &eventStream{owner: owner, id: id, ring: ring, conn: conn, lines: make(chan string, EventStreamBatch), done: make(chan struct{})}
+		go func() {
+			defer close(s.lines)
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				select {
+				case s.lines <- scanner.Text():
+				case <-s.done:
+					return
+				}
+			}
+		}()
+		return eventStreamMsg{owner: owner, stream: s}
+	}
+}
+
+// next waits for the stream's next lines, returning those already received
+// along with the first so a burst costs a single update.
+func (s *eventStream) next() tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-s.lines
+		if !ok {
+			return eventStreamEndMsg{owner: s.owner, id: s.id}
+		}
+		lines := []string{line}
+		for len(lines) < EventStreamBatch {
+			select {
+			case line, ok := <-s.lines:
+				if !ok {
+					return eventLinesMsg{owner: s.owner, id: s.id, lines: lines}
+				}
+				lines = append(lines, line)
+			default:
+				return eventLinesMsg{owner: s.owner, id: s.id, lines: lines}
+			}
+		}
+		return eventLinesMsg{owner: s.owner, id: s.id, lines: lines}
+	}
+}
+
+// close ends the stream: closing the connection stops a pending read and
+// done a pending send, as nothing reads the lines any more.
+func (s *eventStream) close() {
+	close(s.done)
+	s.conn.Close()
+}
+
+// handleEvents stores the sinks listed by "show events".
+func (m *model) handleEvents(output string) {
+	m.eventList.rings = events.ParseRings(output)
+	if m.activeTab == eventsTab && m.eventList.stream == nil {
+		m.applyFilter()
+	}
+}
+
+// followRing opens a stream on the selected ring, closing the current one.
+func (m *model) followRing(newOnly bool) tea.Cmd {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return nil
+	}
+	for _, r := range m.eventList.rings {
+		if r.Name == row[0] && !r.Tailable() {
+			m.message = r.Name + " is a " + r.Type + " sink and cannot be followed"
+			return nil
+		}
+	}
+	return m.eventList.follow(m.config, eventsTab, row[0], newOnly)
+}
+
+// follow opens a stream on ring, closing the current one.
+func (v *eventView) follow(cfg Config, owner tab, ring string, newOnly bool) tea.Cmd {
+	v.stop()
+	v.seq++
+	return openEventStream(cfg, owner, v.seq, ring, newOnly)
+}
+
+// stop closes the followed ring, if any, and drops its lines.
+func (v *eventView) stop() {
+	if v.stream != nil {
+		v.stream.close()
+	}
+	v.stream = nil
+	v.buffer = events.Buffer{Max: MaxEventLines}
+	v.paused = false
+	v.held = 0
+	v.ended = ""
+}
+
+// togglePause pauses or resumes the followed ring.
+func (v *eventView) togglePause() {
+	v.paused = !v.paused
+	if !v.paused {
+		v.held = 0
+	}
+}
+
+// content returns the followed lines, without those received while paused.
+func (v eventView) content() string {
+	lines := v.buffer.Lines
+	if v.paused {
+		lines = lines[:len(lines)-v.held]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// status describes the followed ring for the views.
+func (v eventView) status() (ring string, paused bool, held, dropped int, ended string) {
+	if v.stream == nil {
+		return "", false, 0, 0, ""
+	}
+	return v.stream.ring, v.paused, v.held, v.buffer.Dropped, v.ended
+}
+
+// eventViewOf returns the eventView a stream of owner belongs to.
+func (m *model) eventViewOf(owner tab) *eventView {
+	if owner == tracesTab {
+		return &m.traceList.tail
+	}
+	return &m.eventList
+}
+
+// showStream updates the tab owning a stream once it opened or stopped.
+func (m *model) showStream(owner tab) {
+	if m.activeTab != owner {
+		return
+	}
+	if owner == tracesTab {
+		m.showTraces()
+	} else {
+		m.showEvents()
+	}
+}
+
+// handleEventStream starts reading an opened stream.
+func (m *model) handleEventStream(msg eventStreamMsg) tea.Cmd {
+	if msg.err != nil {
+		m.message = "Cannot follow events: " + msg.err.Error()
+		return nil
+	}
+	v := m.eventViewOf(msg.owner)
+	if msg.stream.id != v.seq {
+		// Replaced while connecting
+		msg.stream.close()
+		return nil
+	}
+	v.stream = msg.stream
+	m.filterInput = ""
+	m.showStream(msg.owner)
+	return msg.stream.next()
+}
+
+// handleEventLines appends the lines of a followed ring and scrolls to
+// them unless paused.
+func (m *model) handleEventLines(msg eventLinesMsg) tea.Cmd {
+	v := m.eventViewOf(msg.owner)
+	s := v.stream
+	if s == nil || msg.id != s.id {
+		return nil
+	}
+	v.buffer.Append(msg.lines...)
+	if v.paused {
+		v.held = min(v.held+len(msg.lines), len(v.buffer.Lines))
+	} else if m.activeTab == msg.owner {
+		m.applyViewportFilter()
+		m.viewport.GotoBottom()
+	}
+	return s.next()
+}
+
+func (m *model) handleEventStreamEnd(msg eventStreamEndMsg) {
+	v := m.eventViewOf(msg.owner)
+	if s := v.stream; s != nil && msg.id == s.id {
+		v.ended = "stream closed by HAProxy"
+	}
+}
+
+// showEvents sets up the sink table, or the viewport on the followed ring.
+func (m *model) showEvents() {
+	if m.eventList.stream != nil {
+		m.showStreamViewport(m.eventList)
+		return
+	}
+	m.table = events.InitializeTable()
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+// showStreamViewport shows the lines of v, scrolled to the last one unless
+// paused.
+func (m *model) showStreamViewport(v eventView) {
+	m.applyViewportFilter()
+	if !v.paused {
+		m.viewport.GotoBottom()
+	}
+}
+
+func (m model) eventRows() []table.Row {
+	return events.Rows(m.eventList.rings)
+}
+
+// updateEventKeys handles the keys of the Events tab. handled is false for
+// keys left to the common handling.
+func (m *model) updateEventKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	if m.eventList.stream == nil {
+		switch msg.String() {
+		case "enter":
+			return m.followRing(false), true
+		case "n":
+			return m.followRing(true), true
+		}
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "p":
+		m.eventList.togglePause()
+		m.showEvents()
+		return nil, true
+	case "esc", "backspace":
+		m.eventList.stop()
+		m.viewportFilterInput = ""
+		m.showEvents()
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEventStream(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "haproxy.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		cmd, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- cmd
+		conn.Write([]byte("<0>event one\n<0>event two\n"))
+	}()
+
+	msg := openEventStream(Config{socketPath: socket}, eventsTab, 1, "buf0", true)()
+	opened, ok := msg.(eventStreamMsg)
+	if !ok || opened.err != nil {
+		t.Fatalf("openEventStream() = %#v", msg)
+	}
+	if cmd := <-received; cmd != "show events buf0 -w -n\n" {
+		t.Errorf("command = %q", cmd)
+	}
+
+	var lines []string
+	for {
+		switch msg := opened.stream.next()().(type) {
+		case eventLinesMsg:
+			lines = append(lines, msg.lines...)
+			continue
+		case eventStreamEndMsg:
+			if msg.id != 1 {
+				t.Errorf("end id = %d, want 1", msg.id)
+			}
+		}
+		break
+	}
+	if !reflect.DeepEqual(lines, []string{"<0>event one", "<0>event two"}) {
+		t.Errorf("lines = %q", lines)
+	}
+}
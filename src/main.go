@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"charm.land/bubbles/v2/table"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/events"
 	"github.com/knowald/lazyhap/src/views/stats"
 )
 
@@ -27,6 +29,14 @@ const (
 	threadsTab
 	activityTab
 	eventsTab
+	auditTab
+	rollingTab
+	mapsTab
+	aclTab
+	stickTablesTab
+	crtListsTab
+	caFilesTab
+	tracesTab
 )
 
 type model struct {
@@ -36,13 +46,15 @@ type model struct {
 	activeTab    tab
 	tabs         []string
 	info         string
-	errors       string
-	pools        string
-	certs        string
+	errorList    errorList
+	poolList     poolList
+	certList     certList
 	threads      string
-	sessions     string
+	threadList   threadList
+	sessionList  sessionView
 	activity     string
-	events       string
+	activityList activityList
+	eventList    eventView
 	err          error
 	lastFetch    time.Time
 	width        int
@@ -53,17 +65,39 @@ type model struct {
 	filterInput  string
 	allStatsRows  []table.Row
 	allInfoRows   []table.Row
+	allAuditRows  []table.Row
+	maps          patternView
+	acls          patternView
+	sticks        stickView
+	crtLists      crtListView
+	caFiles       caFileView
+	traceList     traceView
 	sortColumn     int
 	sortAscending  bool
 	confirmMode    bool
 	confirmAction  string
 	confirmBackend string
 	confirmServer  string
-	weightMode     bool
-	weightInput    string
-	weightBackend  string
-	weightServer   string
+	confirmCommand string
+	confirmTracked *serverRef
+	checksMode     bool
+	textMode       bool
+	textInput      string
+	textAction     textAction
+	numericMode    bool
+	numericInput   string
+	numericAction  numericAction
+	limitsMode     bool
+	tabPicker      bool
+	undoStack      []undoEntry
+	undoSeq        int
+	rolling        *rollingOp
+	ramps          map[serverRef]*rampOp
+	rampSeq        int
+	rampDraft      rampDraft
+	rampPending    rampOp
 	connected          bool
+	cliLevel           string
 	viewportFilterMode  bool
 	viewportFilterInput string
 }
@@ -73,7 +107,6 @@ type (
 	errorMsg   string
 	poolsMsg   string
 	sessionMsg string
-	certsMsg    string
 	threadsMsg  string
 	activityMsg string
 	eventsMsg   string
@@ -81,6 +114,25 @@ type (
 
 type clearMessageMsg struct{}
 
+// commandResultMsg carries HAProxy's reply to a command sent from an action.
+type commandResultMsg struct {
+	command string
+	reply   string
+}
+
+// summary returns the first line of the reply, or a confirmation when
+// HAProxy answered with nothing (the usual case for successful commands).
+func (r commandResultMsg) summary() string {
+	reply := strings.TrimSpace(r.reply)
+	if reply == "" {
+		return "Done: " + r.command
+	}
+	if idx := strings.Index(reply, "\n"); idx >= 0 {
+		reply = reply[:idx]
+	}
+	return reply
+}
+
 func fetchStats(cfg Config) tea.Msg {
 	conn, err := net.Dial("unix", cfg.socketPath)
 	if err != nil {
@@ -130,6 +182,8 @@ func fetchStats(cfg Config) tea.Msg {
 			fields[33],             // Rate/s
 			fields[13],             // Errors
 			fields[18],             // Weight
+			fields[36],             // Check status
+			fields[62],             // Agent status
 		}
 
 		rows = append(rows, row)
@@ -143,17 +197,57 @@ func fetchStats(cfg Config) tea.Msg {
 	return rows
 }
 
+// parseArgs parses the command line: an optional socket path, with flags
+// before or after it, so "lazyhap /run/haproxy.sock --read-only" is read-only
+// too rather than ignoring the flag.
+func parseArgs(args []string, readOnlyDefault bool) (socketPath string, readOnly bool, err error) {
+	fs := flag.NewFlagSet("lazyhap", flag.ContinueOnError)
+	ro := fs.Bool("read-only", readOnlyDefault, "disable all actions that change HAProxy state")
+	if err := fs.Parse(args); err != nil {
+		return "", false, err
+	}
+	if fs.NArg() > 0 {
+		socketPath = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return "", false, err
+		}
+		if fs.NArg() > 0 {
+			return "", false, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+		}
+	}
+	return socketPath, *ro, nil
+}
+
 func main() {
 	// Load config from file
 	appConfig := LoadConfig()
 
+	socketPath, readOnly, err := parseArgs(os.Args[1:], appConfig.ReadOnly)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	cfg := Config{
-		socketPath: appConfig.SocketPath,
+		socketPath:   appConfig.SocketPath,
+		readOnly:     readOnly,
+		rolling:      defaultRollingConfig(),
+		certWarnDays: appConfig.CertExpiryWarningDays,
+		certFiles:    appConfig.CertFiles,
+	}
+	if steps := parseRollingSteps(appConfig.Rolling.Steps); len(steps) > 0 {
+		cfg.rolling.steps = steps
 	}
+	cfg.rolling.drainTimeout = appConfig.Rolling.DrainTimeout
+	cfg.rolling.healthTimeout = appConfig.Rolling.HealthTimeout
+	cfg.rolling.rampDuration = appConfig.Rolling.RampDuration
 
 	// Command-line argument overrides config file
-	if len(os.Args) > 1 {
-		cfg.socketPath = os.Args[1]
+	if socketPath != "" {
+		cfg.socketPath = socketPath
 	}
 
 	// Initial state
@@ -162,12 +256,20 @@ func main() {
 	vp.SetHeight(DefaultViewportHeight)
 
 	m := model{
-		table:      stats.InitializeTable(),
-		viewport:   vp,
-		tabs:       []string{"Stats", "Info", "Errors", "Memory", "Sessions", "Certs", "Threads", "Activity", "Events"},
-		activeTab:  statsTab,
-		config:     cfg,
-		sortColumn: -1,
+		table:       stats.InitializeTable(),
+		viewport:    vp,
+		tabs:        []string{"Stats", "Info", "Errors", "Memory", "Sessions", "Certs", "Threads", "Activity", "Events", "Audit", "Rolling", "Maps", "ACL", "Stick Tables", "Crt-lists", "CA/CRL", "Traces"},
+		activeTab:   statsTab,
+		config:      cfg,
+		sortColumn:  -1,
+		maps:        patternView{kind: kindMap},
+		acls:        patternView{kind: kindACL},
+		sticks:      stickView{sortColumn: -1},
+		sessionList: sessionView{sort: tableSort{column: -1}},
+		certList:    certList{sort: tableSort{column: -1}},
+		poolList:    poolList{sort: tableSort{column: 3}}, // allocated bytes, largest first
+		activityList: activityList{sort: tableSort{column: -1}},
+		eventList:    eventView{buffer: events.Buffer{Max: MaxEventLines}},
 	}
 
 	p := tea.NewProgram(m)
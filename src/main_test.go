@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		socket   string
+		readOnly bool
+		wantErr  bool
+	}{
+		{name: "no arguments", args: nil},
+		{name: "flag before socket", args: []string{"--read-only", "/run/haproxy.sock"}, socket: "/run/haproxy.sock", readOnly: true},
+		{name: "flag after socket", args: []string{"/run/haproxy.sock", "--read-only"}, socket: "/run/haproxy.sock", readOnly: true},
+		{name: "socket only", args: []string{"/run/haproxy.sock"}, socket: "/run/haproxy.sock"},
+		{name: "unknown flag after socket", args: []string{"/run/haproxy.sock", "--read-onyl"}, wantErr: true},
+		{name: "extra argument", args: []string{"/run/haproxy.sock", "/other.sock"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socket, readOnly, err := parseArgs(tt.args, false)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseArgs(%q) succeeded; want an error", tt.args)
+				}
+				return
+			}
+			if err != nil || socket != tt.socket || readOnly != tt.readOnly {
+				t.Errorf("parseArgs(%q) = %q, %v, %v; want %q, %v", tt.args, socket, readOnly, err, tt.socket, tt.readOnly)
+			}
+		})
+	}
+}
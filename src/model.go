@@ -9,8 +9,19 @@ import (
 	"charm.land/bubbles/v2/table"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/activity"
+	"github.com/knowald/lazyhap/src/views/audit"
+	"github.com/knowald/lazyhap/src/views/certs"
+	"github.com/knowald/lazyhap/src/views/crtlists"
+	errorview "github.com/knowald/lazyhap/src/views/error"
 	"github.com/knowald/lazyhap/src/views/info"
+	"github.com/knowald/lazyhap/src/views/maps"
+	"github.com/knowald/lazyhap/src/views/pools"
+	"github.com/knowald/lazyhap/src/views/rolling"
+	"github.com/knowald/lazyhap/src/views/sessions"
 	"github.com/knowald/lazyhap/src/views/stats"
+	"github.com/knowald/lazyhap/src/views/sticktables"
+	"github.com/knowald/lazyhap/src/views/threads"
 )
 
 func (m model) Init() tea.Cmd {
@@ -20,10 +31,11 @@ func (m model) Init() tea.Cmd {
 		func() tea.Msg { return fetchErrors(m.config) },
 		func() tea.Msg { return fetchPools(m.config) },
 		func() tea.Msg { return fetchSessions(m.config) },
-		func() tea.Msg { return fetchCerts(m.config) },
+		func() tea.Msg { return fetchCerts(m.config, nil, true, true) },
 		func() tea.Msg { return fetchThreads(m.config) },
 		func() tea.Msg { return fetchActivity(m.config) },
 		func() tea.Msg { return fetchEvents(m.config) },
+		func() tea.Msg { return fetchCLILevel(m.config) },
 	)
 }
 
@@ -78,47 +90,276 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 
 	case errorMsg:
-		m.errors = string(msg)
+		m.handleErrors(string(msg))
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchErrors(m.config)
 		})
 
 	case poolsMsg:
-		m.pools = string(msg)
+		m.handlePools(string(msg))
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchPools(m.config)
 		})
 
 	case sessionMsg:
-		m.sessions = string(msg)
+		m.sessionList.rows = sessions.ParseSessions(string(msg))
+		if m.activeTab == sessionsTab && m.sessionList.detailID == "" {
+			m.applyFilter()
+		}
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchSessions(m.config)
 		})
 
+	case sessionDetailMsg:
+		if msg.id != m.sessionList.detailID {
+			return m, nil
+		}
+		if msg.ended() {
+			// The session ended, e.g. after a shutdown
+			m.message = "Session " + msg.id + " no longer exists"
+			m.sessionList.detailID = ""
+			if m.activeTab == sessionsTab {
+				m.switchTab(sessionsTab)
+			}
+			return m, nil
+		}
+		m.sessionList.detail = msg.output
+		return m, nil
+
+	case certFileMsg:
+		if msg.create {
+			m.handleNewCertFile(msg)
+			if m.confirmMode {
+				return m, nil
+			}
+		} else {
+			m.handleCertFile(msg)
+		}
+		return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+			return clearMessageMsg{}
+		})
+
+	case certTransactionMsg:
+		return m, m.handleCertTransaction(msg)
+
+	case ocspMsg:
+		m.certList.ocsp = msg
+		if m.activeTab == certsTab && m.certList.showOCSP {
+			m.applyFilter()
+		}
+		return m, nil
+
 	case certsMsg:
-		m.certs = string(msg)
+		m.certList.certs = msg.certs
+		m.certList.transactions = msg.transactions
+		if m.activeTab == certsTab {
+			m.applyFilter()
+		}
+		if !msg.periodic {
+			return m, nil
+		}
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
-			return fetchCerts(m.config)
+			return certsTickMsg{}
 		})
 
+	case certsTickMsg:
+		return m, m.fetchCertList(true)
+
 	case threadsMsg:
-		m.threads = string(msg)
+		m.handleThreads(string(msg))
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchThreads(m.config)
 		})
 
 	case activityMsg:
-		m.activity = string(msg)
+		m.handleActivity(string(msg))
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchActivity(m.config)
 		})
 
 	case eventsMsg:
-		m.events = string(msg)
+		m.handleEvents(string(msg))
 		return m, tea.Tick(RefreshInterval, func(t time.Time) tea.Msg {
 			return fetchEvents(m.config)
 		})
 
+	case tracesMsg:
+		m.handleTraces(msg)
+		return m, nil
+
+	case traceSettingsMsg:
+		m.handleTraceSettings(msg)
+		return m, nil
+
+	case eventStreamMsg:
+		return m, m.handleEventStream(msg)
+
+	case eventLinesMsg:
+		return m, m.handleEventLines(msg)
+
+	case eventStreamEndMsg:
+		m.handleEventStreamEnd(msg)
+		return m, nil
+
+	case commandResultMsg:
+		m.message = msg.summary()
+		refresh := m.refreshActiveTab()
+		if m.activeTab != statsTab {
+			// Keep the Stats table current for actions started elsewhere
+			refresh = tea.Batch(refresh, func() tea.Msg { return fetchStats(m.config) })
+		}
+		return m, tea.Batch(
+			refresh,
+			tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+				return clearMessageMsg{}
+			}),
+		)
+
+	case trackedActionMsg:
+		if msg.entry != nil {
+			m.undoSeq++
+			msg.entry.id = m.undoSeq
+			m.undoStack = append(m.undoStack, *msg.entry)
+			if len(m.undoStack) > MaxUndoEntries {
+				m.undoStack = m.undoStack[len(m.undoStack)-MaxUndoEntries:]
+			}
+		}
+		m.message = msg.summary()
+		return m, tea.Batch(
+			func() tea.Msg { return fetchStats(m.config) },
+			tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+				return clearMessageMsg{}
+			}),
+		)
+
+	case undoResultMsg:
+		if msg.undone {
+			m.dropUndo(msg.id)
+		}
+		m.message = msg.message
+		return m, tea.Batch(
+			func() tea.Msg { return fetchStats(m.config) },
+			tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+				return clearMessageMsg{}
+			}),
+		)
+
+	case rollingStartedMsg:
+		if msg.err != "" {
+			m.message = msg.err
+			return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+				return clearMessageMsg{}
+			})
+		}
+		cmd := m.beginRolling(msg.backend, msg.servers, msg.skipped)
+		return m, tea.Batch(cmd, m.switchTab(rollingTab))
+
+	case rampTickMsg:
+		return m, m.handleRampTick(msg)
+
+	case rampAppliedMsg:
+		ended := m.handleRampApplied(msg)
+		if m.activeTab == statsTab {
+			m.applySortAndFilter()
+		}
+		if ended {
+			reason := ""
+			if msg.reply != "" {
+				reason = "stopped: " + msg.reply
+			}
+			return m, tea.Batch(m.rollingRampEnded(msg.id, reason), tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+				return clearMessageMsg{}
+			}))
+		}
+		return m, nil
+
+	case rollingMsg:
+		return m, m.handleRollingMsg(msg)
+
+	case cliLevelMsg:
+		m.cliLevel = string(msg)
+		return m, nil
+
+	case patternListMsg:
+		pv := m.patternsFor(msg.kind)
+		pv.list = maps.ParseList(msg.output)
+		if m.activePatterns() == pv && pv.openID == "" {
+			m.applyFilter()
+		}
+		return m, nil
+
+	case patternEntriesMsg:
+		pv := m.patternsFor(msg.kind)
+		if msg.id != pv.openID || pv.pending != nil {
+			return m, nil
+		}
+		pv.entries = parsePatternEntries(msg.kind, msg.output)
+		if m.activePatterns() == pv {
+			m.applyFilter()
+		}
+		return m, nil
+
+	case patternFileMsg:
+		m.handlePatternFile(msg)
+		return m, nil
+
+	case stickListMsg:
+		m.sticks.list = sticktables.ParseList(string(msg))
+		if m.activeTab == stickTablesTab && m.sticks.openName == "" {
+			m.applyFilter()
+		}
+		return m, nil
+
+	case stickEntriesMsg:
+		m.handleStickEntries(msg)
+		return m, nil
+
+	case crtListMsg:
+		m.crtLists.list = crtlists.ParseList(string(msg))
+		if m.activeTab == crtListsTab && m.crtLists.openFile == "" {
+			m.applyFilter()
+		}
+		return m, nil
+
+	case caFilesMsg:
+		m.caFiles.files = msg.files
+		m.caFiles.transactions = msg.transactions
+		if m.activeTab == caFilesTab {
+			m.applyFilter()
+		}
+		return m, nil
+
+	case caFileMsg:
+		m.handleCAFile(msg)
+		if m.confirmMode {
+			return m, nil
+		}
+		return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+			return clearMessageMsg{}
+		})
+
+	case crtListEntriesMsg:
+		if msg.file == m.crtLists.openFile {
+			m.crtLists.entries = crtlists.ParseEntries(msg.output)
+			if m.activeTab == crtListsTab {
+				m.applyFilter()
+			}
+		}
+		return m, nil
+
+	case patternTestMsg:
+		m.message = msg.summary()
+		return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+			return clearMessageMsg{}
+		})
+
+	case auditMsg:
+		m.allAuditRows = auditRows(msg)
+		if m.activeTab == auditTab {
+			m.applyFilter()
+		}
+		return m, nil
+
 	case clearMessageMsg:
 		m.message = ""
 		return m, nil
@@ -129,8 +370,70 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "y":
 				m.confirmMode = false
-				if m.confirmAction == "kill" {
+				switch m.confirmAction {
+				case "kill":
 					return m, killServerSessions(m.config, m.confirmBackend, m.confirmServer)
+				case "trace":
+					return m, m.runTraceCommand()
+				case "command":
+					if ref := m.confirmTracked; ref != nil {
+						return m, trackedAction(m.config, m.confirmCommand, []serverRef{*ref}, m.confirmCommand)
+					}
+					return m, runServerCommand(m.config, m.confirmCommand)
+				case "rolling":
+					return m, startRolling(m.config, m.confirmBackend)
+				case "abort rolling":
+					return m, m.abortRolling("aborted by operator")
+				case "ramp":
+					stopped := m.stopRollingRamp(m.rampPending.ref, "replaced")
+					cmd := m.beginRamp()
+					return m, tea.Batch(stopped, cmd, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+						return clearMessageMsg{}
+					}))
+				case "cancel ramp":
+					ref := serverRef{m.confirmBackend, m.confirmServer}
+					stopped := m.stopRollingRamp(ref, "cancelled")
+					m.cancelRamp(ref)
+					m.applySortAndFilter()
+					return m, tea.Batch(stopped, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+						return clearMessageMsg{}
+					}))
+				case "pattern update":
+					if pv := m.activePatterns(); pv != nil && pv.pending != nil {
+						update := pv.pending
+						pv.pending = nil
+						m.showPatterns(pv)
+						return m, applyPatternUpdate(m.config, pv.kind, pv.openID, update.entries)
+					}
+					return m, nil
+				case "ca-file update":
+					if p := m.caFiles.pending; p != nil {
+						m.caFiles.pending = nil
+						return m, updateCAFile(m.config, p.kind, p.name, p.data)
+					}
+					return m, nil
+				case "cert replace":
+					if r := m.certList.replacement; r != nil && r.Stage == "" {
+						r.Stage = "set ssl cert"
+						return m, setCert(m.config, r.Current.Name, m.certList.pem)
+					}
+					return m, nil
+				case "crt-list add":
+					if a := m.crtLists.add; a != nil {
+						m.crtLists.add = nil
+						return m, addCrtListEntry(m.config, *a)
+					}
+					return m, nil
+				case "new cert":
+					if c := m.crtLists.newCert; c != nil {
+						m.crtLists.newCert = nil
+						m.crtLists.lastCert = c.name
+						return m, createCert(m.config, c.name, c.data)
+					}
+					return m, nil
+				case "undo":
+					// Kept on the stack until undone, so a refused undo can be retried
+					return m, undoAction(m.config, m.undoStack[len(m.undoStack)-1])
 				}
 			case "n", "esc":
 				m.confirmMode = false
@@ -138,42 +441,127 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Handle weight input mode
-		if m.weightMode {
+		// Handle tab picker
+		if m.tabPicker {
+			if msg.String() == "esc" {
+				m.tabPicker = false
+				return m, nil
+			}
+			if i, ok := tabPickerIndex(msg.String()); ok && i < len(m.tabs) {
+				m.tabPicker = false
+				return m, m.switchTab(tab(i))
+			}
+			return m, nil
+		}
+
+		// Handle checks submenu
+		if m.checksMode {
+			if msg.String() == "esc" {
+				m.checksMode = false
+				return m, nil
+			}
+			action, ok := findCheckAction(msg.String())
+			if !ok {
+				// Stay in the submenu, its hint lists the valid keys
+				return m, nil
+			}
+			m.checksMode = false
+			if action.input != "" {
+				backend, server := m.confirmBackend, m.confirmServer
+				m.startTextInput(textAction{
+					label:  action.input,
+					target: backend + "/" + server,
+					next: func(m *model, value string) tea.Cmd {
+						m.askConfirmCommand(action.command(backend, server, value))
+						return nil
+					},
+				}, "")
+				return m, nil
+			}
+			m.askConfirmCommand(action.command(m.confirmBackend, m.confirmServer, ""))
+			return m, nil
+		}
+
+		// Handle text input mode
+		if m.textMode {
 			switch msg.String() {
 			case "enter":
-				m.weightMode = false
-				if m.weightInput != "" {
-					w, err := strconv.Atoi(m.weightInput)
-					if err != nil || w < 0 || w > 256 {
-						m.message = "Invalid weight (must be 0-256)"
+				m.textMode = false
+				if m.textInput != "" || m.textAction.optional {
+					return m, m.textAction.next(&m, m.textInput)
+				}
+				return m, nil
+			case "esc":
+				m.textMode = false
+				m.textInput = ""
+				return m, nil
+			case "backspace":
+				if len(m.textInput) > 0 {
+					m.textInput = m.textInput[:len(m.textInput)-1]
+				}
+				return m, nil
+			case "space":
+				m.textInput += " "
+				return m, nil
+			default:
+				if len(msg.String()) == 1 {
+					m.textInput += msg.String()
+				}
+				return m, nil
+			}
+		}
+
+		// Handle global limits menu
+		if m.limitsMode {
+			m.limitsMode = false
+			if limit, ok := findGlobalLimitAction(msg.String()); ok {
+				m.startNumericInput(limit.action, limit.current(m.infoValue(limit.infoKey)))
+			}
+			return m, nil
+		}
+
+		// Handle numeric input mode
+		if m.numericMode {
+			switch msg.String() {
+			case "enter":
+				m.numericMode = false
+				if m.numericInput != "" {
+					v, ok := m.numericAction.parse(m.numericInput)
+					if !ok {
+						m.message = m.numericAction.invalidMessage()
 						return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
 							return clearMessageMsg{}
 						})
 					}
-					return m, setServerWeight(m.config, m.weightBackend, m.weightServer, w)
+					if m.numericAction.next != nil {
+						return m, m.numericAction.next(&m, v)
+					}
+					m.confirmMode = true
+					m.confirmAction = "command"
+					m.confirmCommand = m.numericAction.command(v)
+					m.confirmTracked = m.numericAction.server
 				}
 				return m, nil
 			case "esc":
-				m.weightMode = false
-				m.weightInput = ""
+				m.numericMode = false
+				m.numericInput = ""
 				return m, nil
 			case "backspace":
-				if len(m.weightInput) > 0 {
-					m.weightInput = m.weightInput[:len(m.weightInput)-1]
+				if len(m.numericInput) > 0 {
+					m.numericInput = m.numericInput[:len(m.numericInput)-1]
 				}
 				return m, nil
 			default:
 				c := msg.String()
-				if len(c) == 1 && c[0] >= '0' && c[0] <= '9' && len(m.weightInput) < 3 {
-					m.weightInput += c
+				if len(c) == 1 && c[0] >= '0' && c[0] <= '9' && len(m.numericInput) < m.numericAction.maxDigits() {
+					m.numericInput += c
 				}
 				return m, nil
 			}
 		}
 
 		// Handle filter input
-		if m.filterMode && (m.activeTab == statsTab || m.activeTab == infoTab) {
+		if m.filterMode && m.isTableTab() {
 			switch msg.String() {
 			case "enter":
 				m.filterMode = false
@@ -181,11 +569,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "esc":
 				m.filterMode = false
 				m.filterInput = ""
-				if m.activeTab == statsTab {
-					m.table.SetRows(m.allStatsRows)
-				} else {
-					m.table.SetRows(m.allInfoRows)
-				}
+				m.applyFilter()
 				return m, nil
 			case "backspace":
 				if len(m.filterInput) > 0 {
@@ -228,9 +612,80 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Refuse mutating actions the socket or read-only mode doesn't allow
+		if level, ok := actionLevels[m.activeTab][msg.String()]; ok {
+			if reason := m.actionBlockedReason(level); reason != "" {
+				m.message = "Action disabled: " + reason
+				return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+					return clearMessageMsg{}
+				})
+			}
+		}
+
+		if pv := m.activePatterns(); pv != nil {
+			if cmd, handled := m.updatePatternKeys(pv, msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == threadsTab {
+			if cmd, handled := m.updateThreadKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == activityTab {
+			if cmd, handled := m.updateActivityKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == eventsTab {
+			if cmd, handled := m.updateEventKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == poolsTab {
+			if cmd, handled := m.updatePoolKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == certsTab {
+			if cmd, handled := m.updateCertKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == errorTab {
+			if cmd, handled := m.updateErrorKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == sessionsTab {
+			if cmd, handled := m.updateSessionKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == stickTablesTab {
+			if cmd, handled := m.updateStickKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == crtListsTab {
+			if cmd, handled := m.updateCrtListKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == caFilesTab {
+			if cmd, handled := m.updateCAFileKeys(msg); handled {
+				return m, cmd
+			}
+		}
+		if m.activeTab == tracesTab {
+			if cmd, handled := m.updateTraceKeys(msg); handled {
+				return m, cmd
+			}
+		}
+
 		switch msg.String() {
 		case "/":
-			if m.activeTab == statsTab || m.activeTab == infoTab {
+			if m.isTableTab() {
 				m.filterMode = true
 				m.filterInput = ""
 				return m, nil
@@ -247,6 +702,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showHelp = false
 				return m, nil
 			}
+			if revert := m.leaveTraces(); revert != nil {
+				return m, tea.Sequence(revert, tea.Quit)
+			}
 			return m, tea.Quit
 		case "j", "down":
 			// Forward to table/viewport for navigation
@@ -311,7 +769,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
+		case "H":
+			if m.activeTab == statsTab {
+				selectedRow := m.table.SelectedRow()
+				if len(selectedRow) >= 3 {
+					backend := selectedRow[1]
+					server := selectedRow[2]
+					if server != "FRONTEND" && server != "BACKEND" {
+						m.checksMode = true
+						m.confirmBackend = backend
+						m.confirmServer = server
+						return m, nil
+					}
+				}
+			}
 		case "c":
+			if m.activeTab == rollingTab && m.rolling != nil && m.rolling.waiting {
+				return m, m.continueRolling()
+			}
 			if m.activeTab == statsTab {
 				m.message = "Counters cleared"
 				return m, tea.Batch(
@@ -328,14 +803,87 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					backend := selectedRow[1]
 					server := selectedRow[2]
 					if server != "FRONTEND" && server != "BACKEND" {
-						m.weightMode = true
-						m.weightInput = ""
-						m.weightBackend = backend
-						m.weightServer = server
+						m.startNumericInput(weightAction(backend, server), selectedRow[12])
 						return m, nil
 					}
 				}
 			}
+		case "m":
+			if m.activeTab == statsTab {
+				selectedRow := m.table.SelectedRow()
+				if len(selectedRow) >= 7 {
+					name := selectedRow[1]
+					server := selectedRow[2]
+					switch server {
+					case "FRONTEND":
+						m.startNumericInput(frontendMaxconnAction(name), selectedRow[6])
+						return m, nil
+					case "BACKEND":
+						// Backends have no runtime maxconn of their own
+					default:
+						m.startNumericInput(serverMaxconnAction(name, server), selectedRow[6])
+						return m, nil
+					}
+				}
+			}
+		case "u":
+			if m.activeTab == statsTab {
+				if len(m.undoStack) == 0 {
+					m.message = "Nothing to undo"
+					return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+						return clearMessageMsg{}
+					})
+				}
+				m.confirmMode = true
+				m.confirmAction = "undo"
+				return m, nil
+			}
+		case "W":
+			if m.activeTab == statsTab {
+				selectedRow := m.table.SelectedRow()
+				if len(selectedRow) >= 13 {
+					backend := selectedRow[1]
+					server := selectedRow[2]
+					if server != "FRONTEND" && server != "BACKEND" {
+						m.confirmBackend = backend
+						m.confirmServer = server
+						if _, ok := m.ramps[serverRef{backend, server}]; ok {
+							m.confirmMode = true
+							m.confirmAction = "cancel ramp"
+							return m, nil
+						}
+						m.startRampInput(backend, server, selectedRow[12])
+						return m, nil
+					}
+				}
+			}
+		case "O":
+			if m.activeTab == statsTab {
+				selectedRow := m.table.SelectedRow()
+				if len(selectedRow) >= 3 && selectedRow[2] != "FRONTEND" {
+					if m.rolling != nil && !m.rolling.finished {
+						m.message = "A rolling operation is already running"
+						return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+							return clearMessageMsg{}
+						})
+					}
+					m.confirmMode = true
+					m.confirmAction = "rolling"
+					m.confirmBackend = selectedRow[1]
+					return m, nil
+				}
+			}
+		case "a":
+			if m.activeTab == rollingTab && m.rolling != nil && !m.rolling.finished {
+				m.confirmMode = true
+				m.confirmAction = "abort rolling"
+				return m, nil
+			}
+		case "L":
+			if m.activeTab == statsTab {
+				m.limitsMode = true
+				return m, nil
+			}
 		case "s":
 			if m.activeTab == statsTab {
 				numCols := len(m.table.Columns())
@@ -354,118 +902,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.applySortAndFilter()
 				return m, nil
 			}
+		case "0":
+			// Tabs past the ninth are reached through the picker
+			m.tabPicker = true
+			return m, nil
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 			// Quick jump to tab by number
 			tabNum := int(msg.String()[0] - '1')
 			if tabNum >= 0 && tabNum < len(m.tabs) {
-				previousTab := m.activeTab
-				m.activeTab = tab(tabNum)
-				m.filterMode = false
-				m.filterInput = ""
-
-				// Handle tab switching logic
-				if m.activeTab == infoTab {
-					m.table = info.InitializeTable()
-					m.applyTableSize()
-					m.table.SetRows(m.allInfoRows)
-					return m, nil
-				} else if m.activeTab == statsTab {
-					oldRows := m.table.Rows()
-					m.table = stats.InitializeTable()
-					m.applyTableSize()
-					if len(oldRows) > 0 {
-						m.table.SetRows(oldRows)
-					}
-					if previousTab != statsTab {
-						return m, func() tea.Msg {
-							return fetchStats(m.config)
-						}
-					}
-				}
-				return m, nil
+				return m, m.switchTab(tab(tabNum))
 			}
 		case "tab", "right", "l":
-			previousTab := m.activeTab
-			m.activeTab = tab((int(m.activeTab) + 1) % len(m.tabs))
-			m.filterMode = false
-			m.filterInput = ""
-			m.viewportFilterMode = false
-			m.viewportFilterInput = ""
-			if m.activeTab == infoTab {
-				m.table = info.InitializeTable()
-				m.applyTableSize()
-				m.table.SetRows(m.allInfoRows)
-				return m, nil
-			} else if m.activeTab == statsTab {
-				oldRows := m.table.Rows()
-				m.table = stats.InitializeTable()
-				m.applyTableSize()
-				if len(oldRows) > 0 {
-					m.table.SetRows(oldRows)
-				}
-				if previousTab != statsTab {
-					return m, func() tea.Msg {
-						return fetchStats(m.config)
-					}
-				}
-			}
-			return m, nil
+			return m, m.switchTab(tab((int(m.activeTab) + 1) % len(m.tabs)))
 		case "shift+tab", "left", "h":
-			previousTab := m.activeTab
-			m.activeTab = tab((int(m.activeTab) - 1 + len(m.tabs)) % len(m.tabs))
-			m.filterMode = false
-			m.filterInput = ""
-			m.viewportFilterMode = false
-			m.viewportFilterInput = ""
-			if m.activeTab == infoTab {
-				m.table = info.InitializeTable()
-				m.applyTableSize()
-				m.table.SetRows(m.allInfoRows)
-				return m, nil
-			} else if m.activeTab == statsTab {
-				oldRows := m.table.Rows()
-				m.table = stats.InitializeTable()
-				m.applyTableSize()
-				if len(oldRows) > 0 {
-					m.table.SetRows(oldRows)
-				}
-				if previousTab != statsTab {
-					return m, func() tea.Msg {
-						return fetchStats(m.config)
-					}
-				}
-				return m, nil
-			}
+			return m, m.switchTab(tab((int(m.activeTab) - 1 + len(m.tabs)) % len(m.tabs)))
 		case "r":
-			switch m.activeTab {
-			case statsTab:
-				return m, func() tea.Msg { return fetchStats(m.config) }
-			case infoTab:
-				return m, func() tea.Msg { return fetchInfo(m.config) }
-			case errorTab:
-				return m, func() tea.Msg { return fetchErrors(m.config) }
-			case poolsTab:
-				return m, func() tea.Msg { return fetchPools(m.config) }
-			case sessionsTab:
-				return m, func() tea.Msg { return fetchSessions(m.config) }
-			case certsTab:
-				return m, func() tea.Msg { return fetchCerts(m.config) }
-			case threadsTab:
-				return m, func() tea.Msg { return fetchThreads(m.config) }
-			case activityTab:
-				return m, func() tea.Msg { return fetchActivity(m.config) }
-			case eventsTab:
-				return m, func() tea.Msg { return fetchEvents(m.config) }
-			}
+			return m, m.refreshActiveTab()
 		case "g":
-			if m.activeTab == statsTab || m.activeTab == infoTab {
+			if m.isTableTab() {
 				m.table.GotoTop()
 			} else {
 				m.viewport.GotoTop()
 			}
 			return m, nil
 		case "G":
-			if m.activeTab == statsTab || m.activeTab == infoTab {
+			if m.isTableTab() {
 				m.table.GotoBottom()
 			} else {
 				m.viewport.GotoBottom()
@@ -486,6 +947,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						})
 					}
 				}
+			} else if m.activeTab == auditTab {
+				selectedRow := m.table.SelectedRow()
+				if len(selectedRow) >= 4 {
+					err := copyToClipboard(selectedRow[3])
+					if err != nil {
+						m.err = err
+					} else {
+						m.message = "Copied to clipboard"
+						return m, tea.Tick(MessageDisplayTime, func(t time.Time) tea.Msg {
+							return clearMessageMsg{}
+						})
+					}
+				}
 			} else if m.activeTab == infoTab {
 				selectedRow := m.table.SelectedRow()
 				if len(selectedRow) >= 2 {
@@ -504,11 +978,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	switch m.activeTab {
-	case statsTab, infoTab:
+	if m.isTableTab() {
 		m.table, cmd = m.table.Update(msg)
 		cmds = append(cmds, cmd)
-	default:
+	} else {
 		m.viewport, cmd = m.viewport.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -516,6 +989,159 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// refreshActiveTab returns the command re-fetching the active tab's data.
+func (m model) refreshActiveTab() tea.Cmd {
+	switch m.activeTab {
+	case statsTab:
+		return func() tea.Msg { return fetchStats(m.config) }
+	case infoTab:
+		return func() tea.Msg { return fetchInfo(m.config) }
+	case errorTab:
+		return func() tea.Msg { return fetchErrors(m.config) }
+	case poolsTab:
+		return func() tea.Msg { return fetchPools(m.config) }
+	case sessionsTab:
+		if id := m.sessionList.detailID; id != "" {
+			return fetchSessionDetail(m.config, id)
+		}
+		return func() tea.Msg { return fetchSessions(m.config) }
+	case certsTab:
+		return m.refreshCerts()
+	case threadsTab:
+		return func() tea.Msg { return fetchThreads(m.config) }
+	case activityTab:
+		return func() tea.Msg { return fetchActivity(m.config) }
+	case eventsTab:
+		return func() tea.Msg { return fetchEvents(m.config) }
+	case auditTab:
+		return fetchAudit
+	case mapsTab:
+		return m.refreshPatterns(&m.maps)
+	case aclTab:
+		return m.refreshPatterns(&m.acls)
+	case stickTablesTab:
+		return m.refreshStickTables()
+	case crtListsTab:
+		return m.refreshCrtLists()
+	case caFilesTab:
+		return m.refreshCAFiles()
+	case tracesTab:
+		return m.refreshTraces()
+	}
+	return nil
+}
+
+// isTableTab reports whether the active tab renders m.table rather than the
+// viewport.
+func (m model) isTableTab() bool {
+	switch m.activeTab {
+	case statsTab, infoTab, poolsTab, auditTab, mapsTab, aclTab, stickTablesTab, crtListsTab, caFilesTab:
+		return true
+	case sessionsTab:
+		return m.sessionList.detailID == "" && !m.sessionList.analytics
+	case errorTab:
+		return !m.errorList.open
+	case certsTab:
+		return m.certList.replacement == nil
+	case threadsTab:
+		return !m.threadList.raw
+	case activityTab:
+		return !m.activityList.raw
+	case eventsTab:
+		return m.eventList.stream == nil
+	case tracesTab:
+		return m.traceList.tail.stream == nil
+	}
+	return false
+}
+
+// switchTab activates t, resetting filter state and rebuilding the table for
+// table-backed tabs.
+func (m *model) switchTab(t tab) tea.Cmd {
+	if m.activeTab == tracesTab && t != tracesTab {
+		// Leaving the Traces tab restores the trace settings changed there
+		if revert := m.leaveTraces(); revert != nil {
+			return tea.Batch(revert, m.switchTab(t))
+		}
+	}
+	previousTab := m.activeTab
+	m.activeTab = t
+	m.filterMode = false
+	m.filterInput = ""
+	m.viewportFilterMode = false
+	m.viewportFilterInput = ""
+
+	switch m.activeTab {
+	case infoTab:
+		m.table = info.InitializeTable()
+		m.applyTableSize()
+		m.table.SetRows(m.allInfoRows)
+	case statsTab:
+		m.table = stats.InitializeTable()
+		m.applyTableSize()
+		m.applySortAndFilter()
+		if previousTab != statsTab {
+			return func() tea.Msg {
+				return fetchStats(m.config)
+			}
+		}
+	case auditTab:
+		m.table = audit.InitializeTable()
+		m.applyTableSize()
+		m.table.SetRows(m.allAuditRows)
+		return fetchAudit
+	case mapsTab, aclTab:
+		pv := m.activePatterns()
+		m.showPatterns(pv)
+		return m.refreshPatterns(pv)
+	case stickTablesTab:
+		m.showStickTables()
+		return m.refreshStickTables()
+	case crtListsTab:
+		m.showCrtLists()
+		return m.refreshCrtLists()
+	case caFilesTab:
+		m.showCAFiles()
+		return m.refreshCAFiles()
+	case tracesTab:
+		m.showTraces()
+		return m.refreshTraces()
+	case poolsTab:
+		m.table = pools.InitializeTable()
+		m.applyTableSize()
+		m.applyFilter()
+	case threadsTab:
+		if !m.threadList.raw {
+			m.table = threads.InitializeTable()
+			m.applyTableSize()
+			m.applyFilter()
+		}
+	case activityTab:
+		if !m.activityList.raw {
+			m.showActivity()
+		}
+	case eventsTab:
+		m.showEvents()
+	case certsTab:
+		// Details are only kept current while the tab is shown
+		m.showCerts()
+		return m.refreshCerts()
+	case errorTab:
+		m.errorList.open = false
+		m.table = errorview.InitializeTable()
+		m.applyTableSize()
+		m.applyFilter()
+	case sessionsTab:
+		m.sessionList.analytics = false
+		if m.sessionList.detailID == "" {
+			m.table = sessions.InitializeTable()
+			m.applyTableSize()
+			m.applyFilter()
+		}
+	}
+	return nil
+}
+
 func (m *model) applyTableSize() {
 	headerHeight := 4
 	footerHeight := 2
@@ -523,31 +1149,71 @@ func (m *model) applyTableSize() {
 	m.table.SetWidth(m.width - 4)
 }
 
+// startNumericInput opens the numeric input prompt for action, prefilled
+// with the current value when it is a plain integer.
+func (m *model) startNumericInput(action numericAction, current string) {
+	m.numericMode = true
+	m.numericAction = action
+	m.numericInput = numericPrefill(current)
+}
+
+// infoValue returns the value of a "show info" field, or "" if unknown.
+func (m model) infoValue(name string) string {
+	for _, row := range m.allInfoRows {
+		if len(row) >= 2 && row[0] == name {
+			return row[1]
+		}
+	}
+	return ""
+}
+
 func (m *model) applyFilter() {
 	if m.activeTab == statsTab {
 		m.applySortAndFilter()
 	} else if m.activeTab == infoTab {
 		m.table.SetRows(filterRows(m.allInfoRows, m.filterInput))
+	} else if m.activeTab == auditTab {
+		m.table.SetRows(filterRows(m.allAuditRows, m.filterInput))
+	} else if pv := m.activePatterns(); pv != nil {
+		m.table.SetRows(filterRows(pv.rows(), m.filterInput))
+	} else if m.activeTab == stickTablesTab {
+		m.table.SetRows(filterRows(m.stickRows(), m.filterInput))
+	} else if m.activeTab == crtListsTab {
+		m.table.SetRows(filterRows(m.crtListRows(), m.filterInput))
+	} else if m.activeTab == caFilesTab {
+		m.table.SetRows(filterRows(m.caFileRows(), m.filterInput))
+	} else if m.activeTab == sessionsTab {
+		m.table.SetRows(filterRows(m.sessionRows(), m.filterInput))
+	} else if m.activeTab == certsTab {
+		m.table.SetRows(filterRows(m.certRows(), m.filterInput))
+	} else if m.activeTab == poolsTab {
+		m.table.SetRows(filterRows(m.poolRows(), m.filterInput))
+	} else if m.activeTab == threadsTab {
+		m.table.SetRows(filterRows(m.threadRows(), m.filterInput))
+	} else if m.activeTab == activityTab {
+		m.table.SetRows(filterRows(m.activityRows(), m.filterInput))
+	} else if m.activeTab == eventsTab {
+		m.table.SetRows(filterRows(m.eventRows(), m.filterInput))
+	} else if m.activeTab == tracesTab {
+		m.table.SetRows(filterRows(m.traceRows(), m.filterInput))
+	} else if m.activeTab == errorTab {
+		m.table.SetRows(filterRows(errorview.Rows(m.errorList.captures), m.filterInput))
 	}
 }
 
 func (m *model) applyViewportFilter() {
 	var content string
 	switch m.activeTab {
-	case errorTab:
-		content = m.errors
 	case sessionsTab:
-		content = m.sessions
-	case certsTab:
-		content = m.certs
+		content = m.sessionList.detail
 	case threadsTab:
 		content = m.threads
 	case activityTab:
 		content = m.activity
 	case eventsTab:
-		content = m.events
-	case poolsTab:
-		content = m.pools
+		content = m.eventList.content()
+	case tracesTab:
+		content = m.traceList.tail.content()
 	}
 	if m.viewportFilterInput != "" {
 		content = filterViewportLines(content, m.viewportFilterInput)
@@ -563,7 +1229,47 @@ func (m *model) applySortAndFilter() {
 	if m.filterMode && m.filterInput != "" {
 		rows = filterRows(rows, m.filterInput)
 	}
-	m.table.SetRows(rows)
+	m.table.SetRows(m.decorateRamps(rows))
+}
+
+// tableSort is the sort order of a table cycled with "s": each column
+// ascending, then descending, then unsorted.
+type tableSort struct {
+	column    int // -1 when unsorted
+	ascending bool
+}
+
+func (s *tableSort) cycle(numCols int) {
+	switch {
+	case s.column == -1:
+		s.column, s.ascending = 0, true
+	case s.ascending:
+		s.ascending = false
+	default:
+		s.column++
+		s.ascending = true
+		if s.column >= numCols {
+			s.column = -1
+		}
+	}
+}
+
+func (s tableSort) apply(rows []table.Row) []table.Row {
+	if s.column < 0 {
+		return rows
+	}
+	return sortRows(rows, s.column, s.ascending)
+}
+
+// describe names the sort column and direction for hint lines.
+func (s tableSort) describe(columns []table.Column) string {
+	if s.column < 0 || s.column >= len(columns) {
+		return ""
+	}
+	if s.ascending {
+		return columns[s.column].Title + " ↑"
+	}
+	return columns[s.column].Title + " ↓"
 }
 
 func sortRows(rows []table.Row, col int, ascending bool) []table.Row {
@@ -590,6 +1296,14 @@ func sortRows(rows []table.Row, col int, ascending bool) []table.Row {
 			bNum, bErr = parseByteValue(b)
 		}
 
+		// Handle HAProxy durations (e.g. "1h02m")
+		if aErr != nil {
+			aNum, aErr = parseDurationValue(a)
+		}
+		if bErr != nil {
+			bNum, bErr = parseDurationValue(b)
+		}
+
 		if aErr == nil && bErr == nil {
 			if ascending {
 				return aNum < bNum
@@ -626,14 +1340,65 @@ func parseByteValue(s string) (float64, error) {
 	return 0, strconv.ErrSyntax
 }
 
+// parseDurationValue converts a duration as HAProxy prints it, such as
+// "5s", "12m05s", "1d02h" or "250ms", to seconds.
+func parseDurationValue(s string) (float64, error) {
+	units := []struct {
+		suffix  string
+		seconds float64
+	}{
+		// "ms" and "us" before "s"
+		{"ms", 0.001}, {"us", 0.000001}, {"d", 86400}, {"h", 3600}, {"m", 60}, {"s", 1},
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, strconv.ErrSyntax
+	}
+	var total float64
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		if i == 0 {
+			return 0, strconv.ErrSyntax
+		}
+		num, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, err
+		}
+		s = s[i:]
+
+		matched := false
+		for _, u := range units {
+			if strings.HasPrefix(s, u.suffix) {
+				total += num * u.seconds
+				s = s[len(u.suffix):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, strconv.ErrSyntax
+		}
+	}
+	return total, nil
+}
+
 // Model get/set
 
 func (m model) GetViewport() viewport.Model {
 	return m.viewport
 }
 
-func (m model) ErrorView() string {
-	return m.errors
+func (m model) ErrorDetail() (errorview.Capture, bool, bool) {
+	c, ok := m.openCapture()
+	return c, m.errorList.hex, ok
+}
+
+func (m model) ErrorsSummary() string {
+	return m.errorList.summary
 }
 
 func (m model) TableView() string {
@@ -648,28 +1413,116 @@ func (m model) GetMessage() string {
 	return m.message
 }
 
-func (m model) CertsView() string {
-	return m.certs
+func (m model) CertsSort() string {
+	return m.certList.sort.describe(m.table.Columns())
+}
+
+func (m model) ExpiringCerts() (count, warnDays int) {
+	return m.expiringCerts(), m.config.certWarnDays
+}
+
+func (m model) CertReplacement() (certs.Replacement, bool) {
+	if m.certList.replacement == nil {
+		return certs.Replacement{}, false
+	}
+	return *m.certList.replacement, true
+}
+
+func (m model) DriftedCerts() int {
+	return m.driftedCerts()
+}
+
+func (m model) OCSPMode() bool {
+	return m.certList.showOCSP
+}
+
+func (m model) StaleOCSP() int {
+	return m.staleOCSP()
+}
+
+func (m model) CertTransactions() []string {
+	return m.certList.transactions
 }
 
 func (m model) ThreadsView() string {
 	return m.threads
 }
 
-func (m model) PoolsView() string {
-	return m.pools
+func (m model) RawThreads() bool {
+	return m.threadList.raw
+}
+
+func (m model) StuckThreads() []int {
+	return threads.Stuck(m.threadList.threads)
+}
+
+func (m model) PoolsSort() string {
+	return m.poolList.sort.describe(m.table.Columns())
+}
+
+func (m model) PoolTotals() (count int, allocated, used uint64) {
+	allocated, used = pools.Totals(m.poolList.pools)
+	return len(m.poolList.pools), allocated, used
+}
+
+func (m model) LeakingPools() int {
+	return m.poolList.history.LeakingPools(PoolLeakSamples)
+}
+
+func (m model) SessionDetail() (id, detail string) {
+	return m.sessionList.detailID, m.sessionList.detail
+}
+
+func (m model) SessionAnalytics() (sessions.Analytics, bool) {
+	if !m.sessionList.analytics {
+		return sessions.Analytics{}, false
+	}
+	return buildSessionAnalytics(m.sessionList.rows), true
 }
 
-func (m model) SessionsView() string {
-	return m.sessions
+func (m model) SessionsSort() string {
+	return m.sessionsSort()
 }
 
 func (m model) ActivityView() string {
 	return m.activity
 }
 
+func (m model) RawActivity() bool {
+	return m.activityList.raw
+}
+
+func (m model) ActivityMode() (absolute bool, threadOrder, sort string) {
+	v := m.activityList
+	return v.absolute, v.orderBy, v.sort.describe(m.table.Columns())
+}
+
+func (m model) ImbalancedMetrics() []string {
+	return activity.Imbalanced(m.activityList.metrics, m.activityList.previous)
+}
+
 func (m model) EventsView() string {
-	return m.events
+	return filterViewportLines(m.eventList.content(), m.viewportFilterInput)
+}
+
+func (m model) EventStream() (ring string, paused bool, held, dropped int, ended string) {
+	return m.eventList.status()
+}
+
+func (m model) OpenTraceSource() string {
+	return m.traceList.open
+}
+
+func (m model) ChangedTraceSources() []string {
+	return m.traceList.changedTraceSources()
+}
+
+func (m model) TraceView() string {
+	return filterViewportLines(m.traceList.tail.content(), m.viewportFilterInput)
+}
+
+func (m model) TraceStream() (ring string, paused bool, held, dropped int, ended string) {
+	return m.traceList.tail.status()
 }
 
 func (m model) FilterMode() bool {
@@ -689,19 +1542,134 @@ func (m model) ConfirmMode() bool {
 }
 
 func (m model) ConfirmPrompt() string {
+	switch m.confirmAction {
+	case "command", "trace":
+		return "Run \"" + m.confirmCommand + "\"? (y/n)"
+	case "ramp":
+		return "Ramp " + m.rampPending.description() + "? (y/n)"
+	case "cancel ramp":
+		return "Cancel weight ramp on " + m.confirmBackend + "/" + m.confirmServer + "? (y/n)"
+	case "rolling":
+		return "Start rolling operation on backend " + m.confirmBackend + "? (y/n)"
+	case "abort rolling":
+		return "Abort rolling operation on " + m.rolling.backend + "? (y/n)"
+	case "pattern update":
+		return m.activePatterns().updatePrompt()
+	case "new cert":
+		return m.crtLists.newCertPrompt()
+	case "crt-list add":
+		a := m.crtLists.add
+		return "Add \"" + strings.TrimSpace(a.cert+" "+a.rest) + "\" to " + a.file + "? (y/n)"
+	case "ca-file update":
+		return m.caFiles.updatePrompt()
+	case "cert replace":
+		return "Replace " + m.certList.replacement.Current.Name + " (set ssl cert, then commit)? (y/n)"
+	case "undo":
+		return "Undo " + m.undoStack[len(m.undoStack)-1].description() + "? (y/n)"
+	}
 	return "Kill all sessions on " + m.confirmBackend + "/" + m.confirmServer + "? (y/n)"
 }
 
-func (m model) WeightMode() bool {
-	return m.weightMode
+func (m model) ChecksMode() bool {
+	return m.checksMode
+}
+
+func (m model) ChecksHint() string {
+	return checksMenuHint()
 }
 
-func (m model) WeightInput() string {
-	return m.weightInput
+func (m model) TextInputMode() bool {
+	return m.textMode
 }
 
-func (m model) WeightServer() string {
-	return m.weightBackend + "/" + m.weightServer
+func (m model) TextInputPrompt() string {
+	if m.textAction.target == "" {
+		return m.textAction.label
+	}
+	return m.textAction.label + " for " + m.textAction.target
+}
+
+func (m model) TextInput() string {
+	return m.textInput
+}
+
+func (m model) NumericMode() bool {
+	return m.numericMode
+}
+
+func (m model) NumericInput() string {
+	return m.numericInput
+}
+
+func (m model) NumericPrompt() string {
+	return m.numericAction.label + " for " + m.numericAction.target
+}
+
+func (m model) NumericBounds() string {
+	return m.numericAction.bounds()
+}
+
+func (m model) ActionsDisabledReason() string {
+	return m.actionBlockedReason(levelAdmin)
+}
+
+func (m model) RollingProgress() (rolling.Progress, bool) {
+	if m.rolling == nil {
+		return rolling.Progress{}, false
+	}
+	return m.rolling.progress(), true
+}
+
+func (m model) RampCount() int {
+	return len(m.ramps)
+}
+
+func (m model) OpenMap() string {
+	return m.maps.title()
+}
+
+func (m model) OpenACL() string {
+	return m.acls.title()
+}
+
+func (m model) OpenStickTable() string {
+	return m.stickTitle()
+}
+
+func (m model) OpenCAFile() string {
+	if m.caFiles.openName == "" {
+		return ""
+	}
+	return m.caFiles.openKind + " " + m.caFiles.openName
+}
+
+func (m model) CATransactions() []string {
+	return m.caFiles.transactions
+}
+
+func (m model) StaleCAFiles() int {
+	return m.staleCAFiles()
+}
+
+func (m model) OpenCrtList() string {
+	return m.crtLists.openFile
+}
+
+func (m model) PendingUpdate() bool {
+	pv := m.activePatterns()
+	return pv != nil && pv.pending != nil
+}
+
+func (m model) UndoDepth() int {
+	return len(m.undoStack)
+}
+
+func (m model) LimitsMode() bool {
+	return m.limitsMode
+}
+
+func (m model) LimitsHint() string {
+	return globalLimitsHint()
 }
 
 func (m model) ViewportFilterMode() bool {
@@ -724,6 +1692,10 @@ func (m model) SortColumn() int {
 	return m.sortColumn
 }
 
+func (m model) AuditLogPath() string {
+	return getAuditLogPath()
+}
+
 func (m model) SortAscending() bool {
 	return m.sortAscending
 }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// numericAction is a runtime command taking a single integer argument, such
+// as a server weight or a maxconn/rate limit. The format string receives the
+// target as %[1]s and the value as %[2]d.
+type numericAction struct {
+	label  string
+	target string
+	format string
+	min    int
+	max    int
+	// server is set for actions that change state restored by undo
+	server *serverRef
+	// next, when set, receives the value instead of it being sent as a
+	// command, letting an action chain several prompts
+	next func(m *model, value int) tea.Cmd
+}
+
+// globalLimitAction is an entry of the global limits menu. Its current value
+// is read from "show info" under infoKey, divided by infoDiv when the info
+// field and the command use different units.
+type globalLimitAction struct {
+	key     string
+	action  numericAction
+	infoKey string
+	infoDiv int64
+}
+
+var globalLimitActions = []globalLimitAction{
+	{
+		key:     "m",
+		action:  numericAction{label: "Global maxconn", target: "global", format: "set maxconn global %[2]d", min: 1, max: MaxConnLimit},
+		infoKey: "Maxconn",
+	},
+	{
+		key:     "c",
+		action:  numericAction{label: "Connection rate limit", target: "global", format: "set rate-limit connections global %[2]d", min: 0, max: MaxRateLimit},
+		infoKey: "ConnRateLimit",
+	},
+	{
+		key:     "s",
+		action:  numericAction{label: "Session rate limit", target: "global", format: "set rate-limit sessions global %[2]d", min: 0, max: MaxRateLimit},
+		infoKey: "SessRateLimit",
+	},
+	{
+		key:     "l",
+		action:  numericAction{label: "SSL session rate limit", target: "global", format: "set rate-limit ssl-sessions global %[2]d", min: 0, max: MaxRateLimit},
+		infoKey: "SslRateLimit",
+	},
+	{
+		// show info reports bytes/s, the command takes kB/s
+		key:     "z",
+		action:  numericAction{label: "HTTP compression rate limit (kB/s)", target: "global", format: "set rate-limit http-compression global %[2]d", min: 0, max: MaxRateLimit},
+		infoKey: "CompressBpsRateLim",
+		infoDiv: 1024,
+	},
+}
+
+func weightAction(backend, server string) numericAction {
+	return numericAction{
+		label:  "Weight",
+		target: backend + "/" + server,
+		format: "set server %[1]s weight %[2]d",
+		min:    0,
+		max:    MaxServerWeight,
+		server: &serverRef{backend, server},
+	}
+}
+
+func serverMaxconnAction(backend, server string) numericAction {
+	return numericAction{
+		label:  "Maxconn",
+		target: backend + "/" + server,
+		format: "set maxconn server %[1]s %[2]d",
+		min:    0,
+		max:    MaxConnLimit,
+	}
+}
+
+func frontendMaxconnAction(frontend string) numericAction {
+	return numericAction{
+		label:  "Maxconn",
+		target: frontend,
+		format: "set maxconn frontend %[1]s %[2]d",
+		min:    1,
+		max:    MaxConnLimit,
+	}
+}
+
+// command builds the runtime API command for value.
+func (a numericAction) command(value int) string {
+	return fmt.Sprintf(a.format, a.target, value)
+}
+
+// parse validates input against the action's bounds.
+func (a numericAction) parse(input string) (int, bool) {
+	v, err := strconv.Atoi(input)
+	if err != nil || v < a.min || v > a.max {
+		return 0, false
+	}
+	return v, true
+}
+
+// invalidMessage is shown when the input falls outside the action's bounds.
+func (a numericAction) invalidMessage() string {
+	return fmt.Sprintf("Invalid %s (must be %s)", strings.ToLower(a.label), a.bounds())
+}
+
+// maxDigits is the longest input accepted for this action.
+func (a numericAction) maxDigits() int {
+	return len(strconv.Itoa(a.max))
+}
+
+// bounds renders the accepted range for the input hint.
+func (a numericAction) bounds() string {
+	return fmt.Sprintf("%d-%d", a.min, a.max)
+}
+
+// findGlobalLimitAction returns the global limits menu entry bound to key.
+func findGlobalLimitAction(key string) (globalLimitAction, bool) {
+	for _, a := range globalLimitActions {
+		if a.key == key {
+			return a, true
+		}
+	}
+	return globalLimitAction{}, false
+}
+
+// current returns the value reported by "show info" for this limit, or ""
+// when it is not available.
+func (a globalLimitAction) current(infoValue string) string {
+	if infoValue == "" {
+		return ""
+	}
+	if a.infoDiv > 0 {
+		return strconv.FormatInt(stringToInt(infoValue)/a.infoDiv, 10)
+	}
+	return infoValue
+}
+
+// globalLimitsHint renders the global limits menu as a "key: label" hint line.
+func globalLimitsHint() string {
+	parts := make([]string, 0, len(globalLimitActions)+1)
+	for _, a := range globalLimitActions {
+		parts = append(parts, a.key+": "+strings.ToLower(a.action.label))
+	}
+	parts = append(parts, "esc: cancel")
+	return strings.Join(parts, "  ")
+}
+
+// numericPrefill keeps only a plain integer current value, dropping things
+// like empty cells or "-" so the input starts clean.
+func numericPrefill(value string) string {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.Atoi(value); err != nil {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestNumericActionCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   numericAction
+		value    int
+		expected string
+	}{
+		{
+			name:     "server weight",
+			action:   weightAction("web", "web-01"),
+			value:    50,
+			expected: "set server web/web-01 weight 50",
+		},
+		{
+			name:     "server maxconn",
+			action:   serverMaxconnAction("web", "web-01"),
+			value:    200,
+			expected: "set maxconn server web/web-01 200",
+		},
+		{
+			name:     "frontend maxconn",
+			action:   frontendMaxconnAction("http-in"),
+			value:    1000,
+			expected: "set maxconn frontend http-in 1000",
+		},
+		{
+			name:     "global rate limit",
+			action:   globalLimitActions[1].action,
+			value:    300,
+			expected: "set rate-limit connections global 300",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.action.command(tt.value)
+			if result != tt.expected {
+				t.Errorf("command(%d) = %q; want %q", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNumericActionParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		valid  bool
+		expect int
+	}{
+		{name: "lower bound", input: "0", valid: true, expect: 0},
+		{name: "upper bound", input: "256", valid: true, expect: 256},
+		{name: "above bound", input: "257", valid: false},
+		{name: "not a number", input: "abc", valid: false},
+		{name: "empty", input: "", valid: false},
+	}
+
+	action := weightAction("web", "web-01")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := action.parse(tt.input)
+			if ok != tt.valid {
+				t.Fatalf("parse(%q) ok = %v; want %v", tt.input, ok, tt.valid)
+			}
+			if ok && v != tt.expect {
+				t.Errorf("parse(%q) = %d; want %d", tt.input, v, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/acl"
+	"github.com/knowald/lazyhap/src/views/maps"
+)
+
+// Pattern reference kinds, as used in runtime API commands.
+const (
+	kindMap = "map"
+	kindACL = "acl"
+)
+
+// patternView is the state of a tab browsing maps or ACLs: the loaded
+// references and, once one is opened, its entries.
+type patternView struct {
+	kind     string
+	list     []table.Row
+	entries  []table.Row
+	openID   string
+	openFile string
+	pending  *patternUpdate
+}
+
+// patternEntry is a map key and value, or an ACL pattern with no value.
+type patternEntry struct {
+	key   string
+	value string
+}
+
+// patternUpdate is the content of a local file waiting to replace the
+// opened map or ACL, with its diff against the loaded entries.
+type patternUpdate struct {
+	file    string
+	entries []patternEntry
+	diff    []table.Row
+}
+
+type patternListMsg struct {
+	kind   string
+	output string
+}
+
+type patternEntriesMsg struct {
+	kind   string
+	id     string
+	output string
+}
+
+// patternFileMsg carries a local file loaded for an atomic update.
+type patternFileMsg struct {
+	kind    string
+	id      string
+	file    string
+	entries []patternEntry
+	current []table.Row
+	err     error
+}
+
+func fetchPatternList(cfg Config, kind string) tea.Cmd {
+	return func() tea.Msg {
+		return patternListMsg{kind: kind, output: execCommand(cfg, "show "+kind)}
+	}
+}
+
+func fetchPatternEntries(cfg Config, kind, id string) tea.Cmd {
+	return func() tea.Msg {
+		return patternEntriesMsg{kind: kind, id: id, output: execCommand(cfg, "show "+kind+" #"+id)}
+	}
+}
+
+// loadPatternFile reads path along with the current entries of the map or
+// ACL it is meant to replace.
+func loadPatternFile(cfg Config, kind, id, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := patternFileMsg{kind: kind, id: id, file: path}
+		msg.entries, msg.err = readPatternFile(path, kind == kindMap)
+		if msg.err == nil {
+			msg.current = parsePatternEntries(kind, execCommand(cfg, "show "+kind+" #"+id))
+		}
+		return msg
+	}
+}
+
+// readPatternFile parses a map file ("key value" lines) or an ACL file (one
+// pattern per line), skipping blank lines and comments.
+func readPatternFile(path string, withValues bool) ([]patternEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []patternEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !withValues {
+			entries = append(entries, patternEntry{key: line})
+			continue
+		}
+		// Key and value are separated by spaces or tabs
+		key, value := line, ""
+		if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+			key, value = line[:i], strings.TrimSpace(line[i:])
+		}
+		entries = append(entries, patternEntry{key: key, value: value})
+	}
+	return entries, scanner.Err()
+}
+
+// parsePatternEntries parses "show map/acl #<id>" output into the rows of
+// the entries table for kind.
+func parsePatternEntries(kind, output string) []table.Row {
+	if kind == kindACL {
+		return acl.ParseEntries(output)
+	}
+	return maps.ParseEntries(output)
+}
+
+// diffPatterns compares the loaded entries with the ones from a file and
+// returns Change, Key, Old and New rows: "+" for added keys, "-" for removed
+// ones and "~" for changed values. Without values, as for ACLs, rows only
+// hold Change and Pattern.
+func diffPatterns(current []table.Row, next []patternEntry, withValues bool) []table.Row {
+	old := make(map[string]string, len(current))
+	for _, row := range current {
+		switch {
+		case len(row) >= 2:
+			old[row[0]] = row[1]
+		case len(row) == 1:
+			old[row[0]] = ""
+		}
+	}
+	seen := make(map[string]bool, len(next))
+
+	var diff []table.Row
+	for _, e := range next {
+		seen[e.key] = true
+		prev, ok := old[e.key]
+		switch {
+		case !ok:
+			diff = append(diff, table.Row{"+", e.key, "", e.value})
+		case prev != e.value:
+			diff = append(diff, table.Row{"~", e.key, prev, e.value})
+		}
+	}
+
+	var removed []table.Row
+	for key, value := range old {
+		if !seen[key] {
+			removed = append(removed, table.Row{"-", key, value, ""})
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i][1] < removed[j][1] })
+
+	diff = append(diff, removed...)
+	if !withValues {
+		for i, row := range diff {
+			diff[i] = row[:2]
+		}
+	}
+	return diff
+}
+
+// parsePreparedVersion extracts the version from a "prepare map/acl" reply
+// such as "New version created: 3".
+func parsePreparedVersion(reply string) (string, bool) {
+	_, version, ok := strings.Cut(strings.TrimSpace(reply), "New version created:")
+	version = strings.TrimSpace(version)
+	if !ok || version == "" {
+		return "", false
+	}
+	return version, true
+}
+
+// applyPatternUpdate atomically replaces the content of a map or ACL: it
+// prepares a new version, adds every entry to it and commits it. If any step
+// fails, the uncommitted version is cleared and the loaded content is left
+// untouched.
+func applyPatternUpdate(cfg Config, kind, id string, entries []patternEntry) tea.Cmd {
+	return func() tea.Msg {
+		ref := "#" + id
+		label := fmt.Sprintf("atomic update of %s %s", kind, ref)
+
+		reply := execAction(cfg, "prepare "+kind+" "+ref)
+		version, ok := parsePreparedVersion(reply)
+		if !ok {
+			return commandResultMsg{command: label, reply: "Update failed: " + strings.TrimSpace(reply)}
+		}
+
+		for _, e := range entries {
+			cmd := fmt.Sprintf("add %s @%s %s %s", kind, version, ref, cliEscape(e.key))
+			if kind == kindMap {
+				cmd += " " + cliEscape(e.value)
+			}
+			if reply := strings.TrimSpace(execAction(cfg, cmd)); reply != "" {
+				execAction(cfg, fmt.Sprintf("clear %s @%s %s", kind, version, ref))
+				return commandResultMsg{command: label, reply: fmt.Sprintf("Update aborted at %q: %s", e.key, reply)}
+			}
+		}
+
+		if reply := strings.TrimSpace(execAction(cfg, fmt.Sprintf("commit %s @%s %s", kind, version, ref))); reply != "" {
+			execAction(cfg, fmt.Sprintf("clear %s @%s %s", kind, version, ref))
+			return commandResultMsg{command: label, reply: "Commit failed, update aborted: " + reply}
+		}
+		return commandResultMsg{command: label, reply: fmt.Sprintf("Committed version %s of %s %s (%d entries)", version, kind, ref, len(entries))}
+	}
+}
+
+// patternTestMsg carries the reply of "get map/acl" for a tested value.
+type patternTestMsg struct {
+	kind  string
+	id    string
+	value string
+	reply string
+}
+
+// testPattern looks value up in a map or ACL with "get map/acl", which
+// reports whether and how it matches without changing anything.
+func testPattern(cfg Config, kind, id, value string) tea.Cmd {
+	return func() tea.Msg {
+		reply := execCommand(cfg, fmt.Sprintf("get %s #%s %s", kind, id, cliEscape(value)))
+		return patternTestMsg{kind: kind, id: id, value: value, reply: reply}
+	}
+}
+
+// summary condenses a "get map/acl" reply such as
+// `type=ip, case=sensitive, match=yes, idx=tree, key="10.0.0.0/8"` to one
+// line.
+func (msg patternTestMsg) summary() string {
+	reply := strings.Join(strings.Fields(msg.reply), " ")
+	if reply == "" {
+		reply = "no reply"
+	}
+	return fmt.Sprintf("%s #%s, %s: %s", msg.kind, msg.id, msg.value, reply)
+}
+
+// patternsFor returns the view state for a pattern kind.
+func (m *model) patternsFor(kind string) *patternView {
+	if kind == kindACL {
+		return &m.acls
+	}
+	return &m.maps
+}
+
+// activePatterns returns the view state of the active tab, or nil when it
+// doesn't browse maps or ACLs.
+func (m *model) activePatterns() *patternView {
+	switch m.activeTab {
+	case mapsTab:
+		return &m.maps
+	case aclTab:
+		return &m.acls
+	}
+	return nil
+}
+
+// refreshPatterns re-fetches the opened map or ACL, or the list when none is
+// open.
+func (m model) refreshPatterns(pv *patternView) tea.Cmd {
+	if pv.openID != "" {
+		return fetchPatternEntries(m.config, pv.kind, pv.openID)
+	}
+	return fetchPatternList(m.config, pv.kind)
+}
+
+// showPatterns sets up the table for the list, the opened entries, or the
+// diff of a pending update.
+func (m *model) showPatterns(pv *patternView) {
+	switch {
+	case pv.kind == kindACL && pv.pending != nil:
+		m.table = acl.InitializeDiffTable()
+	case pv.kind == kindACL && pv.openID != "":
+		m.table = acl.InitializeEntriesTable()
+	case pv.kind == kindACL:
+		m.table = acl.InitializeListTable()
+	case pv.pending != nil:
+		m.table = maps.InitializeDiffTable()
+	case pv.openID != "":
+		m.table = maps.InitializeEntriesTable()
+	default:
+		m.table = maps.InitializeListTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+// rows returns the rows the table currently shows for pv.
+func (pv *patternView) rows() []table.Row {
+	switch {
+	case pv.pending != nil:
+		return pv.pending.diff
+	case pv.openID != "":
+		return pv.entries
+	default:
+		return pv.list
+	}
+}
+
+// updatePatternKeys handles the keys of a tab browsing maps or ACLs. handled
+// is false for keys left to the common handling.
+func (m *model) updatePatternKeys(pv *patternView, msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	row := m.table.SelectedRow()
+	kind := pv.kind
+
+	if pv.pending != nil {
+		switch msg.String() {
+		case "esc", "backspace":
+			pv.pending = nil
+			m.showPatterns(pv)
+			return m.refreshPatterns(pv), true
+		case "enter":
+			m.confirmMode = true
+			m.confirmAction = "pattern update"
+			return nil, true
+		}
+		return nil, false
+	}
+
+	if pv.openID == "" {
+		if msg.String() == "enter" && len(row) >= 2 {
+			pv.openID = row[0]
+			pv.openFile = row[1]
+			pv.entries = nil
+			m.filterInput = ""
+			m.showPatterns(pv)
+			return fetchPatternEntries(m.config, kind, pv.openID), true
+		}
+		return nil, false
+	}
+
+	id := pv.openID
+	switch msg.String() {
+	case "esc", "backspace":
+		pv.openID = ""
+		pv.openFile = ""
+		m.filterInput = ""
+		m.showPatterns(pv)
+		return m.refreshPatterns(pv), true
+	case "a":
+		if kind == kindACL {
+			m.startTextInput(textAction{
+				label:  "Pattern",
+				target: "acl #" + id,
+				next: func(m *model, pattern string) tea.Cmd {
+					m.askConfirmCommand(fmt.Sprintf("add acl #%s %s", id, cliEscape(pattern)))
+					return nil
+				},
+			}, "")
+			return nil, true
+		}
+		m.startTextInput(textAction{
+			label:  "Key",
+			target: kind + " #" + id,
+			next: func(m *model, key string) tea.Cmd {
+				m.startTextInput(textAction{
+					label:  "Value",
+					target: key,
+					next: func(m *model, value string) tea.Cmd {
+						m.askConfirmCommand(fmt.Sprintf("add %s #%s %s %s", kind, id, cliEscape(key), cliEscape(value)))
+						return nil
+					},
+				}, "")
+				return nil
+			},
+		}, "")
+		return nil, true
+	case "e":
+		if kind == kindMap && len(row) >= 2 {
+			key := row[0]
+			m.startTextInput(textAction{
+				label:  "Value",
+				target: key,
+				next: func(m *model, value string) tea.Cmd {
+					m.askConfirmCommand(fmt.Sprintf("set %s #%s %s %s", kind, id, cliEscape(key), cliEscape(value)))
+					return nil
+				},
+			}, row[1])
+		}
+		return nil, true
+	case "x":
+		if len(row) >= 1 {
+			m.askConfirmCommand(fmt.Sprintf("del %s #%s %s", kind, id, cliEscape(row[0])))
+		}
+		return nil, true
+	case "C":
+		m.askConfirmCommand(fmt.Sprintf("clear %s #%s", kind, id))
+		return nil, true
+	case "t":
+		cfg := m.config
+		m.startTextInput(textAction{
+			label:  "Test value",
+			target: kind + " #" + id,
+			next: func(m *model, value string) tea.Cmd {
+				return testPattern(cfg, kind, id, value)
+			},
+		}, "")
+		return nil, true
+	case "F":
+		cfg := m.config
+		m.startTextInput(textAction{
+			label:  "Load entries from file",
+			target: kind + " #" + id,
+			next: func(m *model, path string) tea.Cmd {
+				return loadPatternFile(cfg, kind, id, path)
+			},
+		}, pv.openFile)
+		return nil, true
+	}
+	return nil, false
+}
+
+// handlePatternFile shows the diff of a loaded file, waiting for the
+// operator to apply it.
+func (m *model) handlePatternFile(msg patternFileMsg) {
+	pv := m.patternsFor(msg.kind)
+	if msg.id != pv.openID {
+		return
+	}
+	if msg.err != nil {
+		m.message = "Failed to load " + msg.file + ": " + msg.err.Error()
+		return
+	}
+	pv.entries = msg.current
+	pv.pending = &patternUpdate{
+		file:    msg.file,
+		entries: msg.entries,
+		diff:    diffPatterns(msg.current, msg.entries, msg.kind == kindMap),
+	}
+	if m.activePatterns() == pv {
+		m.filterInput = ""
+		m.showPatterns(pv)
+	}
+}
+
+// title describes the opened map or ACL, or the pending update, for
+// the hint line.
+func (pv *patternView) title() string {
+	if pv.openID == "" {
+		return ""
+	}
+	title := strings.ToUpper(pv.kind[:1]) + pv.kind[1:] + " #" + pv.openID
+	if pv.kind == kindACL {
+		title = "ACL #" + pv.openID
+	}
+	if pv.openFile != "" {
+		title += " " + pv.openFile
+	}
+	if pv.pending != nil {
+		title += fmt.Sprintf(" ← %s (%d entries, %d changes)", pv.pending.file, len(pv.pending.entries), len(pv.pending.diff))
+	}
+	return title
+}
+
+// updatePrompt asks to apply the pending update.
+func (pv *patternView) updatePrompt() string {
+	if pv == nil || pv.pending == nil {
+		return ""
+	}
+	return fmt.Sprintf("Replace %s #%s with %d entries from %s (%d changes) atomically? (y/n)",
+		pv.kind, pv.openID, len(pv.pending.entries), pv.pending.file, len(pv.pending.diff))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestDiffPatterns(t *testing.T) {
+	current := []table.Row{
+		{"/api", "be_api"},
+		{"/static", "be_static"},
+		{"/old", "be_old"},
+		{"/legacy", "be_old"},
+	}
+	next := []patternEntry{
+		{key: "/api", value: "be_api"},
+		{key: "/static", value: "be_cdn"},
+		{key: "/new", value: "be_new"},
+	}
+
+	expected := []table.Row{
+		{"~", "/static", "be_static", "be_cdn"},
+		{"+", "/new", "", "be_new"},
+		{"-", "/legacy", "be_old", ""},
+		{"-", "/old", "be_old", ""},
+	}
+
+	result := diffPatterns(current, next, true)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("diffPatterns() = %v; want %v", result, expected)
+	}
+
+	if diff := diffPatterns(current[:1], next[:1], true); len(diff) != 0 {
+		t.Errorf("diffPatterns() of identical entries = %v; want no changes", diff)
+	}
+
+	acls := diffPatterns(
+		[]table.Row{{"10.0.0.0/8"}, {"192.168.0.0/16"}},
+		[]patternEntry{{key: "10.0.0.0/8"}, {key: "172.16.0.0/12"}},
+		false,
+	)
+	expectedACL := []table.Row{{"+", "172.16.0.0/12"}, {"-", "192.168.0.0/16"}}
+	if !reflect.DeepEqual(acls, expectedACL) {
+		t.Errorf("diffPatterns() without values = %v; want %v", acls, expectedACL)
+	}
+}
+
+func TestReadPatternFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.map")
+	content := "# hosts\nexample.com   be_web\n\n  api.example.com be_api  \nbare.example.com\ntabbed.example.com\t\tbe_tab\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		withValues bool
+		expected   []patternEntry
+	}{
+		{
+			name:       "map file",
+			withValues: true,
+			expected: []patternEntry{
+				{key: "example.com", value: "be_web"},
+				{key: "api.example.com", value: "be_api"},
+				{key: "bare.example.com"},
+				{key: "tabbed.example.com", value: "be_tab"},
+			},
+		},
+		{
+			name:       "acl file",
+			withValues: false,
+			expected: []patternEntry{
+				{key: "example.com   be_web"},
+				{key: "api.example.com be_api"},
+				{key: "bare.example.com"},
+				{key: "tabbed.example.com\t\tbe_tab"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := readPatternFile(path, tt.withValues)
+			if err != nil {
+				t.Fatalf("readPatternFile() error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("readPatternFile() = %v; want %v", result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := readPatternFile(filepath.Join(t.TempDir(), "missing"), true); err == nil {
+		t.Errorf("readPatternFile() of a missing file returned no error")
+	}
+}
+
+func TestParsePreparedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		expected string
+		ok       bool
+	}{
+		{name: "created", reply: "New version created: 3\n", expected: "3", ok: true},
+		{name: "unknown map", reply: "Unknown map identifier. Please use #<id> or <file>.\n", ok: false},
+		{name: "empty", reply: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parsePreparedVersion(tt.reply)
+			if result != tt.expected || ok != tt.ok {
+				t.Errorf("parsePreparedVersion(%q) = %q, %v; want %q, %v", tt.reply, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/pools"
+)
+
+// poolList is the state of the Memory tab: the pools of the last refresh
+// and the history of their allocations.
+type poolList struct {
+	pools   []pools.Pool
+	history pools.History
+	sort    tableSort
+}
+
+// handlePools stores the pools of a refresh and records their allocations.
+func (m *model) handlePools(output string) {
+	m.poolList.pools = pools.ParsePools(output)
+	if m.poolList.history == nil {
+		m.poolList.history = pools.History{}
+	}
+	m.poolList.history.Record(m.poolList.pools, PoolLeakSamples)
+	if m.activeTab == poolsTab {
+		m.applyFilter()
+	}
+}
+
+// poolRows returns the pools in the selected sort order.
+func (m model) poolRows() []table.Row {
+	return m.poolList.sort.apply(pools.Rows(m.poolList.pools, m.poolList.history, PoolLeakSamples))
+}
+
+// updatePoolKeys handles the keys of the Memory tab. handled is false for
+// keys left to the common handling.
+func (m *model) updatePoolKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	if msg.String() == "s" {
+		m.poolList.sort.cycle(len(m.table.Columns()))
+		m.applyFilter()
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+)
+
+// rampOp moves a server's weight from one value to another in equal steps.
+type rampOp struct {
+	id       int
+	ref      serverRef
+	from     int
+	to       int
+	steps    int
+	step     int // steps applied so far
+	interval time.Duration
+}
+
+// weightAt returns the weight to apply at step k.
+func (r rampOp) weightAt(k int) int {
+	return r.from + (r.to-r.from)*k/r.steps
+}
+
+// cellValue renders the ramp in the Weight column, e.g. "↗40→100".
+func (r rampOp) cellValue() string {
+	arrow := "↗"
+	if r.to < r.from {
+		arrow = "↘"
+	}
+	return fmt.Sprintf("%s%d→%d", arrow, r.weightAt(r.step), r.to)
+}
+
+func (r rampOp) description() string {
+	return fmt.Sprintf("%s weight %d→%d over %s in %d steps",
+		r.ref, r.from, r.to, (r.interval * time.Duration(r.steps)).String(), r.steps)
+}
+
+// rampDraft collects the ramp parameters across the input prompts.
+type rampDraft struct {
+	ref      serverRef
+	from     int
+	to       int
+	duration time.Duration
+}
+
+type rampTickMsg struct {
+	ref serverRef
+	id  int
+}
+
+type rampAppliedMsg struct {
+	ref   serverRef
+	id    int
+	reply string
+}
+
+// startRampInput prompts for the target weight, then the duration and the
+// number of steps, before asking for confirmation.
+func (m *model) startRampInput(backend, server, current string) {
+	ref := serverRef{backend, server}
+	m.rampDraft = rampDraft{ref: ref, from: int(stringToInt(current))}
+
+	target := weightAction(backend, server)
+	target.label = "Ramp target weight"
+	target.next = func(m *model, v int) tea.Cmd {
+		m.rampDraft.to = v
+		m.startNumericInput(numericAction{
+			label:  "Ramp duration (s)",
+			target: ref.String(),
+			min:    1,
+			max:    MaxRampDurationSeconds,
+			next: func(m *model, v int) tea.Cmd {
+				m.rampDraft.duration = time.Duration(v) * time.Second
+				m.startNumericInput(numericAction{
+					label:  "Ramp steps",
+					target: ref.String(),
+					min:    1,
+					max:    MaxRampSteps,
+					next: func(m *model, v int) tea.Cmd {
+						m.confirmRamp(v)
+						return nil
+					},
+				}, strconv.Itoa(DefaultRampSteps))
+				return nil
+			},
+		}, "")
+		return nil
+	}
+	m.startNumericInput(target, "")
+}
+
+func (m *model) confirmRamp(steps int) {
+	d := m.rampDraft
+	m.rampPending = rampOp{
+		ref:      d.ref,
+		from:     d.from,
+		to:       d.to,
+		steps:    steps,
+		interval: d.duration / time.Duration(steps),
+	}
+	m.confirmMode = true
+	m.confirmAction = "ramp"
+}
+
+// beginRamp starts the pending ramp, replacing any ramp already running on
+// the same server. The first step is sent after one interval, so the last
+// lands at the end of the requested duration.
+func (m *model) beginRamp() tea.Cmd {
+	r := m.rampPending
+	m.rampSeq++
+	r.id = m.rampSeq
+	if m.ramps == nil {
+		m.ramps = map[serverRef]*rampOp{}
+	}
+	m.ramps[r.ref] = &r
+	m.message = "Ramping " + r.description()
+	return rampTick(r.ref, r.id, r.interval)
+}
+
+// rampTick schedules the next step of the ramp id on ref.
+func rampTick(ref serverRef, id int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return rampTickMsg{ref: ref, id: id}
+	})
+}
+
+// applyRampStep sends the next weight of the ramp on ref and schedules the
+// one after it.
+func (m *model) applyRampStep(ref serverRef) tea.Cmd {
+	r := m.ramps[ref]
+	r.step++
+	cfg, id, weight := m.config, r.id, r.weightAt(r.step)
+
+	cmds := []tea.Cmd{func() tea.Msg {
+		reply := execAction(cfg, fmt.Sprintf("set server %s weight %d", ref, weight))
+		return rampAppliedMsg{ref: ref, id: id, reply: strings.TrimSpace(reply)}
+	}}
+	if r.step < r.steps {
+		cmds = append(cmds, rampTick(ref, id, r.interval))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleRampTick advances a ramp unless it was cancelled or replaced.
+func (m *model) handleRampTick(msg rampTickMsg) tea.Cmd {
+	r, ok := m.ramps[msg.ref]
+	if !ok || r.id != msg.id {
+		return nil
+	}
+	return m.applyRampStep(msg.ref)
+}
+
+// handleRampApplied stops the ramp when HAProxy refused a step, and removes
+// it once the last step is in. It reports whether the ramp ended.
+func (m *model) handleRampApplied(msg rampAppliedMsg) bool {
+	r, ok := m.ramps[msg.ref]
+	if !ok || r.id != msg.id {
+		return false
+	}
+	switch {
+	case msg.reply != "":
+		delete(m.ramps, msg.ref)
+		m.message = "Ramp on " + msg.ref.String() + " stopped: " + msg.reply
+		return true
+	case r.step >= r.steps:
+		delete(m.ramps, msg.ref)
+		m.message = fmt.Sprintf("Ramp on %s done (weight %d)", msg.ref, r.to)
+		return true
+	}
+	return false
+}
+
+// cancelRamp stops the ramp on ref, leaving the weight where it is.
+func (m *model) cancelRamp(ref serverRef) {
+	if r, ok := m.ramps[ref]; ok {
+		m.message = fmt.Sprintf("Ramp on %s cancelled at weight %d", ref, r.weightAt(r.step))
+		delete(m.ramps, ref)
+	}
+}
+
+// decorateRamps shows running ramps in the Weight column.
+func (m model) decorateRamps(rows []table.Row) []table.Row {
+	if len(m.ramps) == 0 {
+		return rows
+	}
+	out := make([]table.Row, len(rows))
+	for i, row := range rows {
+		out[i] = row
+		if len(row) < 13 {
+			continue
+		}
+		if r, ok := m.ramps[serverRef{row[1], row[2]}]; ok {
+			decorated := make(table.Row, len(row))
+			copy(decorated, row)
+			decorated[12] = r.cellValue()
+			out[i] = decorated
+		}
+	}
+	return out
+}
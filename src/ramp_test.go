@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestRampWeightAt(t *testing.T) {
+	up := rampOp{from: 0, to: 100, steps: 4}
+	down := rampOp{from: 100, to: 10, steps: 3}
+
+	tests := []struct {
+		name     string
+		ramp     rampOp
+		step     int
+		expected int
+	}{
+		{name: "up start", ramp: up, step: 0, expected: 0},
+		{name: "up middle", ramp: up, step: 2, expected: 50},
+		{name: "up end", ramp: up, step: 4, expected: 100},
+		{name: "down first step", ramp: down, step: 1, expected: 70},
+		{name: "down end", ramp: down, step: 3, expected: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.ramp.weightAt(tt.step)
+			if result != tt.expected {
+				t.Errorf("weightAt(%d) = %d; want %d", tt.step, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDecorateRamps(t *testing.T) {
+	ref := serverRef{"web", "web-01"}
+	m := model{ramps: map[serverRef]*rampOp{ref: {ref: ref, from: 0, to: 100, steps: 4, step: 1}}}
+
+	row := func(backend, server, weight string) table.Row {
+		return table.Row{"SV", backend, server, "UP", "0", "0", "", "0", "0 B", "0 B", "0", "0", weight, "L7OK", ""}
+	}
+	rows := []table.Row{row("web", "web-01", "25"), row("web", "web-02", "100")}
+
+	decorated := m.decorateRamps(rows)
+	if decorated[0][12] != "↗25→100" {
+		t.Errorf("ramping server weight cell = %q; want %q", decorated[0][12], "↗25→100")
+	}
+	if decorated[1][12] != "100" {
+		t.Errorf("other server weight cell = %q; want %q", decorated[1][12], "100")
+	}
+	if rows[0][12] != "25" {
+		t.Errorf("decorateRamps() modified its input row")
+	}
+}
+
+func TestBeginRampWaitsOneInterval(t *testing.T) {
+	ref := serverRef{"web", "web-01"}
+	m := model{rampPending: rampOp{ref: ref, from: 0, to: 100, steps: 1, interval: time.Hour}}
+
+	if m.beginRamp() == nil {
+		t.Fatal("beginRamp() scheduled nothing")
+	}
+	if r := m.ramps[ref]; r.step != 0 {
+		t.Errorf("step = %d right after beginRamp(), want 0 until the first interval elapses", r.step)
+	}
+}
@@ -0,0 +1,461 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/rolling"
+)
+
+// rollingStep is one stage a server goes through during a rolling operation.
+type rollingStep string
+
+const (
+	stepDrain         rollingStep = "drain"
+	stepWaitIdle      rollingStep = "wait_idle"
+	stepMaint         rollingStep = "maint"
+	stepDeploy        rollingStep = "deploy"
+	stepReady         rollingStep = "ready"
+	stepWaitHealthy   rollingStep = "wait_healthy"
+	stepRestoreWeight rollingStep = "restore_weight"
+)
+
+var defaultRollingSteps = []rollingStep{
+	stepDrain, stepWaitIdle, stepMaint, stepDeploy, stepReady, stepWaitHealthy, stepRestoreWeight,
+}
+
+// rollingConfig holds the configurable parts of a rolling operation.
+type rollingConfig struct {
+	steps         []rollingStep
+	drainTimeout  time.Duration
+	healthTimeout time.Duration
+	rampDuration  time.Duration // restore_weight ramps over this, 0 to jump
+}
+
+func defaultRollingConfig() rollingConfig {
+	return rollingConfig{
+		steps:         defaultRollingSteps,
+		drainTimeout:  DefaultDrainTimeout,
+		healthTimeout: DefaultHealthTimeout,
+		rampDuration:  DefaultRollingRamp,
+	}
+}
+
+// parseRollingSteps validates step names from the config file, dropping
+// unknown ones. It returns nil when no valid step remains.
+func parseRollingSteps(names []string) []rollingStep {
+	var steps []rollingStep
+	for _, name := range names {
+		step := rollingStep(name)
+		known := false
+		for _, s := range defaultRollingSteps {
+			if s == step {
+				known = true
+				break
+			}
+		}
+		if !known {
+			log.Printf("Warning: ignoring unknown rolling step %q", name)
+			continue
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// rollingOp walks the servers of a backend through a sequence of steps,
+// one server at a time.
+type rollingOp struct {
+	id       int
+	backend  string
+	servers  []serverState // state when the operation started
+	steps    []rollingStep
+	current  int       // index into servers
+	step     int       // index into steps of the running (or next) step
+	started  time.Time // when the running step started
+	chkfail  int64     // failed check count when the server was set ready
+	waiting  bool      // paused for operator confirmation
+	ramp     int       // id of the weight ramp restore_weight waits for
+	finished bool
+	aborted  string
+	status   string
+	log      []string
+}
+
+// rollingStartedMsg carries the servers of the backend to walk through,
+// and those left out because they were out of rotation.
+type rollingStartedMsg struct {
+	backend string
+	servers []serverState
+	skipped []serverRef
+	err     string
+}
+
+// rollingMsg reports the outcome of running or polling a step.
+type rollingMsg struct {
+	id      int
+	done    bool   // the step completed
+	fail    string // non-empty aborts the operation
+	status  string
+	chkfail int64
+}
+
+// startRolling loads the servers of backend in the order HAProxy lists them.
+func startRolling(cfg Config, backend string) tea.Cmd {
+	return func() tea.Msg {
+		servers, skipped := rollingServers(parseServersState(execCommand(cfg, "show servers state "+backend)), backend)
+		if len(servers) == 0 && len(skipped) > 0 {
+			return rollingStartedMsg{backend: backend, err: "All servers of backend " + backend + " are in maintenance or drain"}
+		}
+		if len(servers) == 0 {
+			return rollingStartedMsg{backend: backend, err: "No servers found in backend " + backend}
+		}
+		return rollingStartedMsg{backend: backend, servers: servers, skipped: skipped}
+	}
+}
+
+// rollingServers returns the servers of backend to walk through. Servers in
+// maintenance or drain are skipped: the operation ends with "ready", which
+// would put back in rotation a server an operator took out of it.
+func rollingServers(states []serverState, backend string) (servers []serverState, skipped []serverRef) {
+	for _, s := range states {
+		if s.backend != backend {
+			continue
+		}
+		if s.admin&(adminMaint|adminDrain) != 0 {
+			skipped = append(skipped, s.serverRef)
+			continue
+		}
+		servers = append(servers, s)
+	}
+	return servers, skipped
+}
+
+// fetchServerStat returns the "show stat" fields of one server.
+func fetchServerStat(cfg Config, ref serverRef) ([]string, error) {
+	conn, err := net.Dial("unix", cfg.socketPath)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "show stat\n"); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ",")
+		if len(fields) >= MinStatsFields && fields[0] == ref.backend && fields[1] == ref.server {
+			return fields, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("server %s not found in stats", ref)
+}
+
+// runRollingStep runs step for srv, or polls it for the wait steps.
+func runRollingStep(cfg Config, op rollingOp) tea.Cmd {
+	id := op.id
+	step := op.steps[op.step]
+	srv := op.servers[op.current]
+	started := op.started
+	chkfail := op.chkfail
+	ramped := op.rampsWeight(cfg, srv)
+
+	return func() tea.Msg {
+		switch step {
+		case stepDrain:
+			return rollingAction(cfg, id, fmt.Sprintf("set server %s state drain", srv.serverRef))
+		case stepMaint:
+			return rollingAction(cfg, id, fmt.Sprintf("set server %s state maint", srv.serverRef))
+		case stepRestoreWeight:
+			return rollingAction(cfg, id, fmt.Sprintf("set server %s weight %d", srv.serverRef, srv.weight))
+		case stepReady:
+			if ramped {
+				// Back in rotation at weight 1, restore_weight ramps it up
+				if msg := rollingAction(cfg, id, fmt.Sprintf("set server %s weight 1", srv.serverRef)); msg.fail != "" {
+					return msg
+				}
+			}
+			msg := rollingAction(cfg, id, fmt.Sprintf("set server %s state ready", srv.serverRef))
+			if fields, err := fetchServerStat(cfg, srv.serverRef); err == nil {
+				msg.chkfail = stringToInt(fields[21])
+			}
+			return msg
+
+		case stepWaitIdle:
+			fields, err := fetchServerStat(cfg, srv.serverRef)
+			if err != nil {
+				return rollingMsg{id: id, fail: err.Error()}
+			}
+			scur := stringToInt(fields[4])
+			if scur == 0 {
+				return rollingMsg{id: id, done: true, status: "no sessions left"}
+			}
+			if time.Since(started) > cfg.rolling.drainTimeout {
+				return rollingMsg{id: id, done: true, status: fmt.Sprintf("drain timed out with %d sessions, continuing", scur)}
+			}
+			return rollingMsg{id: id, status: fmt.Sprintf("waiting for %d sessions to finish", scur)}
+
+		case stepWaitHealthy:
+			fields, err := fetchServerStat(cfg, srv.serverRef)
+			if err != nil {
+				return rollingMsg{id: id, fail: err.Error()}
+			}
+			status := fields[17]
+			if strings.HasPrefix(status, "UP") || status == "no check" {
+				return rollingMsg{id: id, done: true, status: "server is " + status}
+			}
+			if stringToInt(fields[21]) > chkfail {
+				return rollingMsg{id: id, fail: fmt.Sprintf("health check failed (%s, %s)", status, strings.TrimSpace(fields[36]))}
+			}
+			if time.Since(started) > cfg.rolling.healthTimeout {
+				return rollingMsg{id: id, fail: "timed out waiting for health checks (" + status + ")"}
+			}
+			return rollingMsg{id: id, status: "waiting for health checks (" + status + ")"}
+		}
+		return rollingMsg{id: id, fail: "unknown step " + string(step)}
+	}
+}
+
+// rampsWeight reports whether srv gets its weight back through a ramp: a
+// ramp duration is set, restore_weight comes after ready and the weight is
+// above 1.
+func (op rollingOp) rampsWeight(cfg Config, srv serverState) bool {
+	if cfg.rolling.rampDuration <= 0 || srv.weight <= 1 {
+		return false
+	}
+	ready := false
+	for _, s := range op.steps {
+		switch {
+		case s == stepReady:
+			ready = true
+		case s == stepRestoreWeight && ready:
+			return true
+		}
+	}
+	return false
+}
+
+// rollingAction sends cmd, failing the step when HAProxy answers with text.
+func rollingAction(cfg Config, id int, cmd string) rollingMsg {
+	if reply := strings.TrimSpace(execAction(cfg, cmd)); reply != "" {
+		return rollingMsg{id: id, fail: cmd + ": " + reply}
+	}
+	return rollingMsg{id: id, done: true, status: cmd}
+}
+
+// rollingUndo records the servers touched by op as a single undo entry.
+func rollingUndo(cfg Config, op rollingOp) tea.Cmd {
+	return func() tea.Msg {
+		action := "rolling operation on " + op.backend
+		summary := "Rolling operation finished"
+		if op.aborted != "" {
+			summary = "Rolling operation aborted: " + op.aborted
+		}
+		msg := trackedActionMsg{commandResultMsg: commandResultMsg{command: action, reply: summary}}
+
+		touched := op.servers[:op.current+1]
+		refs := make([]serverRef, len(touched))
+		for i, s := range touched {
+			refs[i] = s.serverRef
+		}
+		if after, ok := snapshotServers(cfg, refs); ok {
+			msg.entry = &undoEntry{action: action, before: touched, after: after}
+		}
+		return msg
+	}
+}
+
+// beginRolling starts a new operation on the servers of backend.
+func (m *model) beginRolling(backend string, servers []serverState, skipped []serverRef) tea.Cmd {
+	id := 1
+	if m.rolling != nil {
+		id = m.rolling.id + 1
+	}
+	m.rolling = &rollingOp{
+		id:      id,
+		backend: backend,
+		servers: servers,
+		steps:   m.config.rolling.steps,
+	}
+	m.rollingLog(fmt.Sprintf("started on %s (%d servers)", backend, len(servers)))
+	for _, ref := range skipped {
+		m.rollingLog(fmt.Sprintf("skipped %s: in maintenance or drain", ref))
+	}
+	return m.enterRollingStep()
+}
+
+// enterRollingStep starts the step the operation points at, pausing for the
+// operator before deploy steps.
+func (m *model) enterRollingStep() tea.Cmd {
+	op := m.rolling
+	srv := op.servers[op.current].serverRef
+	op.started = time.Now()
+	if op.steps[op.step] == stepDeploy {
+		op.waiting = true
+		op.status = fmt.Sprintf("Deploy %s now, then press c to continue", srv)
+		return nil
+	}
+	if op.steps[op.step] == stepRestoreWeight && op.rampsWeight(m.config, op.servers[op.current]) {
+		return m.rampRollingWeight()
+	}
+	op.status = fmt.Sprintf("%s: %s", srv, op.steps[op.step])
+	return runRollingStep(m.config, *op)
+}
+
+// rampRollingWeight ramps the current server from weight 1 back to the
+// weight it had. The step completes when the ramp ends, in rollingRampEnded.
+func (m *model) rampRollingWeight() tea.Cmd {
+	op := m.rolling
+	srv := op.servers[op.current]
+	steps := min(DefaultRampSteps, srv.weight-1)
+	m.rampPending = rampOp{
+		ref:      srv.serverRef,
+		from:     1,
+		to:       srv.weight,
+		steps:    steps,
+		interval: m.config.rolling.rampDuration / time.Duration(steps),
+	}
+	cmd := m.beginRamp()
+	op.ramp = m.ramps[srv.serverRef].id
+	op.status = fmt.Sprintf("%s: ramping weight 1→%d", srv.serverRef, srv.weight)
+	return cmd
+}
+
+// rollingRampEnded completes the restore_weight step waiting for the ramp
+// id, or aborts the operation when the ramp ended early for reason.
+func (m *model) rollingRampEnded(id int, reason string) tea.Cmd {
+	op := m.rolling
+	if op == nil || op.finished || op.ramp == 0 || op.ramp != id {
+		return nil
+	}
+	op.ramp = 0
+	if reason != "" {
+		return m.abortRolling("weight ramp " + reason)
+	}
+	srv := op.servers[op.current]
+	m.rollingLog(fmt.Sprintf("%s: weight ramped back to %d", srv.serverRef, srv.weight))
+	return m.advanceRolling()
+}
+
+// stopRollingRamp aborts the operation when the operator cancels or replaces
+// the ramp it waits for on ref.
+func (m *model) stopRollingRamp(ref serverRef, reason string) tea.Cmd {
+	if r, ok := m.ramps[ref]; ok {
+		return m.rollingRampEnded(r.id, reason)
+	}
+	return nil
+}
+
+// advanceRolling moves past a completed step: to the next step, to a pause
+// before the next server, or to the end of the operation.
+func (m *model) advanceRolling() tea.Cmd {
+	op := m.rolling
+	op.step++
+	if op.step < len(op.steps) {
+		return m.enterRollingStep()
+	}
+
+	srv := op.servers[op.current].serverRef
+	m.rollingLog(srv.String() + " done")
+	if op.current+1 >= len(op.servers) {
+		op.finished = true
+		op.status = "All servers done"
+		return rollingUndo(m.config, *op)
+	}
+	op.waiting = true
+	op.status = fmt.Sprintf("%s done, press c to continue with %s", srv, op.servers[op.current+1].serverRef)
+	return nil
+}
+
+// continueRolling resumes an operation paused for the operator.
+func (m *model) continueRolling() tea.Cmd {
+	op := m.rolling
+	op.waiting = false
+	if op.step >= len(op.steps) {
+		op.current++
+		op.step = 0
+		return m.enterRollingStep()
+	}
+	// Paused on a deploy step: the operator has deployed
+	m.rollingLog(op.servers[op.current].serverRef.String() + ": deployed")
+	return m.advanceRolling()
+}
+
+// abortRolling stops the operation, leaving the current server as it is.
+func (m *model) abortRolling(reason string) tea.Cmd {
+	op := m.rolling
+	op.finished = true
+	op.waiting = false
+	op.aborted = reason
+	op.status = "Aborted: " + reason
+	if op.ramp != 0 {
+		m.cancelRamp(op.servers[op.current].serverRef)
+		op.ramp = 0
+	}
+	m.rollingLog("aborted: " + reason)
+	return rollingUndo(m.config, *op)
+}
+
+func (m *model) rollingLog(line string) {
+	op := m.rolling
+	op.log = append(op.log, time.Now().Format("15:04:05")+"  "+line)
+	if len(op.log) > MaxRollingLogLines {
+		op.log = op.log[len(op.log)-MaxRollingLogLines:]
+	}
+}
+
+// handleRollingMsg applies the outcome of a step to the running operation.
+func (m *model) handleRollingMsg(msg rollingMsg) tea.Cmd {
+	op := m.rolling
+	if op == nil || op.id != msg.id || op.finished {
+		return nil
+	}
+	if msg.fail != "" {
+		return m.abortRolling(msg.fail)
+	}
+	if !msg.done {
+		op.status = fmt.Sprintf("%s: %s", op.servers[op.current].serverRef, msg.status)
+		cfg, snapshot := m.config, *op
+		return tea.Tick(RollingPollInterval, func(t time.Time) tea.Msg {
+			return runRollingStep(cfg, snapshot)()
+		})
+	}
+
+	m.rollingLog(fmt.Sprintf("%s: %s", op.servers[op.current].serverRef, msg.status))
+	if op.steps[op.step] == stepReady {
+		op.chkfail = msg.chkfail
+	}
+	return m.advanceRolling()
+}
+
+// progress converts the operation for the rolling view.
+func (op rollingOp) progress() rolling.Progress {
+	p := rolling.Progress{
+		Backend:  op.backend,
+		Step:     op.step,
+		Current:  op.current,
+		Status:   op.status,
+		Waiting:  op.waiting,
+		Finished: op.finished,
+		Aborted:  op.aborted != "",
+		Log:      op.log,
+		Elapsed:  time.Since(op.started).Truncate(time.Second).String(),
+	}
+	for _, s := range op.steps {
+		p.Steps = append(p.Steps, string(s))
+	}
+	for _, s := range op.servers {
+		p.Servers = append(p.Servers, s.server)
+	}
+	return p
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRollingSteps(t *testing.T) {
+	steps := parseRollingSteps([]string{"drain", "reboot", "maint", "ready"})
+	expected := []rollingStep{stepDrain, stepMaint, stepReady}
+	if len(steps) != len(expected) {
+		t.Fatalf("parseRollingSteps() returned %v; want %v", steps, expected)
+	}
+	for i := range steps {
+		if steps[i] != expected[i] {
+			t.Errorf("step %d = %q; want %q", i, steps[i], expected[i])
+		}
+	}
+
+	if steps := parseRollingSteps(nil); steps != nil {
+		t.Errorf("parseRollingSteps(nil) = %v; want nil", steps)
+	}
+}
+
+func TestRollingPausesBetweenServers(t *testing.T) {
+	m := model{config: Config{rolling: rollingConfig{steps: []rollingStep{stepDeploy}}}}
+	servers := []serverState{
+		{serverRef: serverRef{"web", "web-01"}, weight: 100},
+		{serverRef: serverRef{"web", "web-02"}, weight: 100},
+	}
+
+	m.beginRolling("web", servers, nil)
+	if !m.rolling.waiting || m.rolling.current != 0 {
+		t.Fatalf("after start: waiting=%v current=%d; want paused on first server", m.rolling.waiting, m.rolling.current)
+	}
+
+	// Deploy confirmed on web-01: pause before moving to web-02
+	m.continueRolling()
+	if !m.rolling.waiting || m.rolling.current != 0 || m.rolling.finished {
+		t.Fatalf("after first deploy: waiting=%v current=%d finished=%v; want paused before next server",
+			m.rolling.waiting, m.rolling.current, m.rolling.finished)
+	}
+
+	// Move on to web-02, which pauses on its deploy step
+	m.continueRolling()
+	if !m.rolling.waiting || m.rolling.current != 1 || m.rolling.step != 0 {
+		t.Fatalf("after continue: waiting=%v current=%d step=%d; want paused on web-02 deploy",
+			m.rolling.waiting, m.rolling.current, m.rolling.step)
+	}
+
+	m.continueRolling()
+	if !m.rolling.finished || m.rolling.aborted != "" {
+		t.Errorf("after last deploy: finished=%v aborted=%q; want finished", m.rolling.finished, m.rolling.aborted)
+	}
+}
+
+func TestRollingAbortsOnFailedStep(t *testing.T) {
+	m := model{config: Config{rolling: rollingConfig{steps: []rollingStep{stepDeploy, stepWaitHealthy}}}}
+	m.beginRolling("web", []serverState{{serverRef: serverRef{"web", "web-01"}}}, nil)
+	m.continueRolling()
+
+	m.handleRollingMsg(rollingMsg{id: m.rolling.id, fail: "health check failed"})
+	if !m.rolling.finished || m.rolling.aborted != "health check failed" {
+		t.Errorf("finished=%v aborted=%q; want aborted on failed step", m.rolling.finished, m.rolling.aborted)
+	}
+
+	// Late results from the aborted operation are ignored
+	if cmd := m.handleRollingMsg(rollingMsg{id: m.rolling.id, done: true}); cmd != nil {
+		t.Errorf("handleRollingMsg() after abort returned a command; want nil")
+	}
+}
+
+func TestRollingServersSkipsOutOfRotation(t *testing.T) {
+	states := []serverState{
+		{serverRef: serverRef{"web", "web-01"}},
+		{serverRef: serverRef{"web", "web-02"}, admin: adminForcedMaint},
+		{serverRef: serverRef{"web", "web-03"}, admin: adminForcedDrain},
+		{serverRef: serverRef{"web", "web-04"}, admin: 0x20}, // maintenance from the configuration
+		{serverRef: serverRef{"api", "api-01"}},
+	}
+
+	servers, skipped := rollingServers(states, "web")
+	if len(servers) != 1 || servers[0].server != "web-01" {
+		t.Errorf("servers = %v, want web-01 only", servers)
+	}
+	if len(skipped) != 3 {
+		t.Errorf("skipped = %v, want web-02, web-03 and web-04", skipped)
+	}
+}
+
+func TestRollingRampsWeightBack(t *testing.T) {
+	ref := serverRef{"web", "web-01"}
+	m := model{config: Config{rolling: rollingConfig{
+		steps:        []rollingStep{stepReady, stepRestoreWeight},
+		rampDuration: 10 * time.Second,
+	}}}
+	m.beginRolling("web", []serverState{{serverRef: ref, weight: 50}}, nil)
+	m.handleRollingMsg(rollingMsg{id: m.rolling.id, done: true})
+
+	r, ok := m.ramps[ref]
+	if !ok || r.from != 1 || r.to != 50 || m.rolling.ramp != r.id {
+		t.Fatalf("after ready: ramp %+v, rolling waits for ramp %d", r, m.rolling.ramp)
+	}
+	if m.rolling.finished {
+		t.Fatal("finished before the ramp ended")
+	}
+
+	// The last step of the ramp completes restore_weight
+	r.step = r.steps
+	updated, _ := m.Update(rampAppliedMsg{ref: ref, id: r.id})
+	m = updated.(model)
+	if !m.rolling.finished || m.rolling.aborted != "" {
+		t.Errorf("after the ramp: finished=%v aborted=%q; want finished", m.rolling.finished, m.rolling.aborted)
+	}
+}
+
+func TestRollingRampStopped(t *testing.T) {
+	ref := serverRef{"web", "web-01"}
+	m := model{config: Config{rolling: rollingConfig{
+		steps:        []rollingStep{stepReady, stepRestoreWeight},
+		rampDuration: 10 * time.Second,
+	}}}
+	m.beginRolling("web", []serverState{{serverRef: ref, weight: 50}}, nil)
+	m.handleRollingMsg(rollingMsg{id: m.rolling.id, done: true})
+
+	m.stopRollingRamp(ref, "cancelled")
+	if m.rolling.aborted != "weight ramp cancelled" {
+		t.Errorf("aborted = %q; want weight ramp cancelled", m.rolling.aborted)
+	}
+
+	// Aborting the operation stops the ramp it waits for
+	m.beginRolling("web", []serverState{{serverRef: ref, weight: 50}}, nil)
+	m.handleRollingMsg(rollingMsg{id: m.rolling.id, done: true})
+	m.abortRolling("aborted by operator")
+	if _, ok := m.ramps[ref]; ok {
+		t.Error("ramp kept running after the abort")
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/sessions"
+)
+
+// sessionView is the state of the Sessions tab: the parsed sessions and,
+// when one is opened, its full "show sess <id>" dump.
+type sessionView struct {
+	rows      []table.Row
+	sort      tableSort
+	detailID  string
+	detail    string
+	analytics bool
+}
+
+type sessionDetailMsg struct {
+	id     string
+	output string
+}
+
+func fetchSessionDetail(cfg Config, id string) tea.Cmd {
+	return func() tea.Msg {
+		return sessionDetailMsg{id: id, output: execCommand(cfg, "show sess "+id)}
+	}
+}
+
+// ended reports whether the session is gone: HAProxy answers "show sess
+// <id>" with nothing or "Session not found." once it ended.
+func (msg sessionDetailMsg) ended() bool {
+	output := strings.TrimSpace(msg.output)
+	return output == "" || strings.HasPrefix(output, "Session not found")
+}
+
+// sessionRows returns the sessions in the selected sort order.
+func (m model) sessionRows() []table.Row {
+	return m.sessionList.sort.apply(m.sessionList.rows)
+}
+
+// updateSessionKeys handles the keys of the Sessions tab. handled is false
+// for keys left to the common handling.
+func (m *model) updateSessionKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	s := &m.sessionList
+
+	if s.detailID != "" {
+		switch msg.String() {
+		case "esc", "backspace":
+			s.detailID = ""
+			s.detail = ""
+			m.viewportFilterInput = ""
+			m.table = sessions.InitializeTable()
+			m.applyTableSize()
+			m.applyFilter()
+			return nil, true
+		case "x":
+			m.askConfirmCommand("shutdown session " + s.detailID)
+			return nil, true
+		}
+		return nil, false
+	}
+
+	if s.analytics {
+		switch msg.String() {
+		case "a", "esc", "backspace":
+			m.switchTab(sessionsTab)
+			return nil, true
+		}
+		return nil, false
+	}
+
+	row := m.table.SelectedRow()
+	switch msg.String() {
+	case "a":
+		s.analytics = true
+		m.viewport.GotoTop()
+		return nil, true
+	case "enter":
+		if len(row) >= 1 {
+			s.detailID = row[0]
+			s.detail = ""
+			m.viewport.GotoTop()
+			return fetchSessionDetail(m.config, s.detailID), true
+		}
+		return nil, true
+	case "x":
+		if len(row) >= 1 {
+			m.askConfirmCommand("shutdown session " + row[0])
+		}
+		return nil, true
+	case "s":
+		s.sort.cycle(len(m.table.Columns()))
+		m.applyFilter()
+		return nil, true
+	}
+	return nil, false
+}
+
+// sessionsSort describes the sort order for the hint line.
+func (m model) sessionsSort() string {
+	if m.sessionList.detailID != "" {
+		return ""
+	}
+	return m.sessionList.sort.describe(m.table.Columns())
+}
+
+// Upper bounds of the session age histogram buckets, in seconds.
+var ageBuckets = []struct {
+	label string
+	upTo  float64
+}{
+	{"<10s", 10},
+	{"10s-1m", 60},
+	{"1m-10m", 600},
+	{"10m-1h", 3600},
+	{"1h-1d", 86400},
+	{">1d", -1},
+}
+
+// buildSessionAnalytics groups sessions by frontend, backend, server and
+// source network, and buckets them by age.
+func buildSessionAnalytics(rows []table.Row) sessions.Analytics {
+	a := sessions.Analytics{Total: len(rows)}
+	frontends, backends, servers, networks := map[string]int{}, map[string]int{}, map[string]int{}, map[string]int{}
+	buckets := make([]int, len(ageBuckets))
+
+	type aged struct {
+		row table.Row
+		age float64
+	}
+	var ages []aged
+
+	for _, row := range rows {
+		if len(row) < 7 {
+			continue
+		}
+		frontends[row[3]]++
+		backends[row[4]]++
+		if row[5] != "<none>" {
+			servers[row[4]+"/"+row[5]]++
+		}
+		networks[sourceNetwork(row[2])]++
+
+		age, err := parseDurationValue(row[6])
+		if err != nil {
+			continue
+		}
+		ages = append(ages, aged{row, age})
+		for i, b := range ageBuckets {
+			if b.upTo < 0 || age < b.upTo {
+				buckets[i]++
+				break
+			}
+		}
+	}
+
+	a.Frontends = topCounts(frontends)
+	a.Backends = topCounts(backends)
+	a.Servers = topCounts(servers)
+	a.Networks = topCounts(networks)
+	for i, b := range ageBuckets {
+		a.Ages = append(a.Ages, sessions.AgeBucket{Label: b.label, N: buckets[i]})
+	}
+
+	sort.SliceStable(ages, func(i, j int) bool { return ages[i].age > ages[j].age })
+	for _, s := range ages[:min(len(ages), MaxAnalyticsRows)] {
+		a.LongestAge = append(a.LongestAge, sessions.LongLived{
+			ID:       s.row[0],
+			Source:   s.row[2],
+			Frontend: s.row[3],
+			Backend:  s.row[4],
+			Server:   s.row[5],
+			Age:      s.row[6],
+		})
+	}
+	return a
+}
+
+// topCounts returns the largest counts, ties broken by name.
+func topCounts(counts map[string]int) []sessions.Count {
+	var out []sessions.Count
+	for name, n := range counts {
+		out = append(out, sessions.Count{Name: name, N: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].N != out[j].N {
+			return out[i].N > out[j].N
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out[:min(len(out), MaxAnalyticsRows)]
+}
+
+// sourceNetwork maps a session source such as "10.1.2.3:50874" to its /24
+// (or /64 for IPv6). Sources that aren't IP addresses, like "unix:1", are
+// grouped by their family.
+func sourceNetwork(src string) string {
+	host := src
+	if i := strings.LastIndex(src, ":"); i > 0 {
+		host = strings.Trim(src[:i], "[]")
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		family, _, _ := strings.Cut(src, ":")
+		return family
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+	return ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+}
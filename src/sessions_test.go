@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseDurationValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{name: "seconds", input: "5s", expected: 5},
+		{name: "minutes and seconds", input: "12m05s", expected: 725},
+		{name: "days and hours", input: "1d02h", expected: 93600},
+		{name: "milliseconds", input: "250ms", expected: 0.25},
+		{name: "plain number", input: "42", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "text", input: "never", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDurationValue(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDurationValue(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("parseDurationValue(%q) = %v; want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortRowsByAge(t *testing.T) {
+	rows := []table.Row{{"a", "5s"}, {"b", "1h02m"}, {"c", "12m05s"}}
+
+	sorted := sortRows(rows, 1, false)
+	expected := []string{"b", "c", "a"}
+	for i, id := range expected {
+		if sorted[i][0] != id {
+			t.Errorf("Row %d = %q; want %q", i, sorted[i][0], id)
+		}
+	}
+}
+
+func TestSourceNetwork(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "ipv4", input: "10.1.2.3:50874", expected: "10.1.2.0/24"},
+		{name: "ipv6", input: "2001:db8:1:2:3::4:41234", expected: "2001:db8:1:2::/64"},
+		{name: "bracketed ipv6", input: "[2001:db8::1]:443", expected: "2001:db8::/64"},
+		{name: "unix socket", input: "unix:1", expected: "unix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sourceNetwork(tt.input)
+			if result != tt.expected {
+				t.Errorf("sourceNetwork(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildSessionAnalytics(t *testing.T) {
+	session := func(id, src, fe, be, srv, age string) table.Row {
+		return table.Row{id, "tcpv4", src, fe, be, srv, age, "EST", ""}
+	}
+	rows := []table.Row{
+		session("0x1", "10.0.0.1:1000", "http", "app", "app1", "2s"),
+		session("0x2", "10.0.0.2:1001", "http", "app", "app2", "5m"),
+		session("0x3", "192.168.1.9:1002", "http", "app", "app1", "3h10m"),
+		session("0x4", "unix:1", "GLOBAL", "<NONE>", "<none>", "0s"),
+	}
+
+	a := buildSessionAnalytics(rows)
+
+	if a.Total != 4 {
+		t.Errorf("Total = %d; want 4", a.Total)
+	}
+	if len(a.Frontends) != 2 || a.Frontends[0].Name != "http" || a.Frontends[0].N != 3 {
+		t.Errorf("Frontends = %v; want http (3) first", a.Frontends)
+	}
+	if len(a.Servers) != 2 || a.Servers[0].Name != "app/app1" || a.Servers[0].N != 2 {
+		t.Errorf("Servers = %v; want app/app1 (2) first and no <none>", a.Servers)
+	}
+	if len(a.Networks) != 3 || a.Networks[0].Name != "10.0.0.0/24" || a.Networks[0].N != 2 {
+		t.Errorf("Networks = %v; want 10.0.0.0/24 (2) first", a.Networks)
+	}
+
+	expectedAges := []int{2, 0, 1, 0, 1, 0}
+	for i, b := range a.Ages {
+		if b.N != expectedAges[i] {
+			t.Errorf("Age bucket %s = %d; want %d", b.Label, b.N, expectedAges[i])
+		}
+	}
+	if len(a.LongestAge) != 4 || a.LongestAge[0].ID != "0x3" || a.LongestAge[1].ID != "0x2" {
+		t.Errorf("LongestAge = %v; want 0x3 then 0x2 first", a.LongestAge)
+	}
+}
+
+func TestSessionDetailEnded(t *testing.T) {
+	tests := []struct {
+		output string
+		ended  bool
+	}{
+		{"", true},
+		{"\n", true},
+		{"Session not found.\n", true},
+		{"0x55c4d5b7c000: [19/Oct/2026:10:00:00.000] id=12 proto=tcpv4 source=10.0.0.1:50874\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := (sessionDetailMsg{output: tt.output}).ended(); got != tt.ended {
+			t.Errorf("ended() for %q = %v; want %v", tt.output, got, tt.ended)
+		}
+	}
+}
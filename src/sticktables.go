@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/sticktables"
+)
+
+// Operators accepted by "show table <name> data.<type> <op> <value>".
+var stickFilterOperators = []string{"eq", "ne", "lt", "le", "gt", "ge"}
+
+// stickView is the state of the Stick Tables tab: the tables and, once one
+// is opened, its entries.
+type stickView struct {
+	list        []table.Row
+	entries     []table.Row
+	dataColumns []string
+	openName    string
+	dataFilter  string // "<type> <op> <value>", applied by HAProxy
+	sortColumn  int    // index into dataColumns, -1 when unsorted
+}
+
+type stickListMsg string
+
+type stickEntriesMsg struct {
+	name   string
+	output string
+}
+
+func fetchStickList(cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		return stickListMsg(execCommand(cfg, "show table"))
+	}
+}
+
+func fetchStickEntries(cfg Config, name, dataFilter string) tea.Cmd {
+	return func() tea.Msg {
+		return stickEntriesMsg{name: name, output: execCommand(cfg, stickShowCommand(name, dataFilter))}
+	}
+}
+
+// stickShowCommand builds the "show table" command for name, with the
+// data filter when one is set.
+func stickShowCommand(name, dataFilter string) string {
+	cmd := "show table " + name
+	if dataFilter != "" {
+		typ, rest, _ := strings.Cut(dataFilter, " ")
+		cmd += " data." + typ + " " + rest
+	}
+	return cmd
+}
+
+// parseStickFilter validates a "<type> <op> <value>" data filter such as
+// "http_req_rate gt 100" and returns it normalized.
+func parseStickFilter(input string) (string, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 3 {
+		return "", fmt.Errorf("expected <type> <op> <value>, e.g. http_req_rate gt 100")
+	}
+	typ, op, value := strings.TrimPrefix(fields[0], "data."), fields[1], fields[2]
+
+	known := false
+	for _, o := range stickFilterOperators {
+		if o == op {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return "", fmt.Errorf("unknown operator %q, use one of %s", op, strings.Join(stickFilterOperators, ", "))
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return "", fmt.Errorf("value %q is not a number", value)
+	}
+	return strings.Join([]string{typ, op, value}, " "), nil
+}
+
+func (m model) refreshStickTables() tea.Cmd {
+	if m.sticks.openName != "" {
+		return fetchStickEntries(m.config, m.sticks.openName, m.sticks.dataFilter)
+	}
+	return fetchStickList(m.config)
+}
+
+// showStickTables sets up the table for the list or the opened table.
+func (m *model) showStickTables() {
+	if m.sticks.openName != "" {
+		m.table = sticktables.InitializeEntriesTable(m.sticks.dataColumns)
+	} else {
+		m.table = sticktables.InitializeListTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+// stickRows returns the rows to show, sorted by the selected data column
+// with the top talkers first.
+func (m model) stickRows() []table.Row {
+	s := m.sticks
+	if s.openName == "" {
+		return s.list
+	}
+	if s.sortColumn >= 0 && s.sortColumn < len(s.dataColumns) {
+		return sortRows(s.entries, sticktables.FixedColumns+s.sortColumn, false)
+	}
+	return s.entries
+}
+
+// handleStickEntries stores the entries of the opened table, rebuilding the
+// table when its data columns changed.
+func (m *model) handleStickEntries(msg stickEntriesMsg) {
+	if msg.name != m.sticks.openName {
+		return
+	}
+	rows, columns := sticktables.ParseEntries(msg.output)
+	m.sticks.entries = rows
+
+	changed := len(columns) != len(m.sticks.dataColumns)
+	for i := 0; !changed && i < len(columns); i++ {
+		changed = columns[i] != m.sticks.dataColumns[i]
+	}
+	if changed {
+		m.sticks.dataColumns = columns
+		m.sticks.sortColumn = -1
+		if m.activeTab == stickTablesTab {
+			m.showStickTables()
+			return
+		}
+	}
+	if m.activeTab == stickTablesTab {
+		m.applyFilter()
+	}
+}
+
+// updateStickKeys handles the keys of the Stick Tables tab. handled is false
+// for keys left to the common handling.
+func (m *model) updateStickKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	row := m.table.SelectedRow()
+
+	if m.sticks.openName == "" {
+		if msg.String() == "enter" && len(row) >= 1 {
+			m.sticks.openName = row[0]
+			m.sticks.entries = nil
+			m.sticks.dataColumns = nil
+			m.sticks.dataFilter = ""
+			m.sticks.sortColumn = -1
+			m.filterInput = ""
+			m.showStickTables()
+			return m.refreshStickTables(), true
+		}
+		return nil, false
+	}
+
+	name := m.sticks.openName
+	switch msg.String() {
+	case "esc", "backspace":
+		m.sticks.openName = ""
+		m.filterInput = ""
+		m.showStickTables()
+		return m.refreshStickTables(), true
+	case "s":
+		// Cycle through the data columns, largest values first
+		m.sticks.sortColumn++
+		if m.sticks.sortColumn >= len(m.sticks.dataColumns) {
+			m.sticks.sortColumn = -1
+		}
+		m.applyFilter()
+		return nil, true
+	case "f":
+		m.startTextInput(textAction{
+			label:  "Data filter (<type> <op> <value>)",
+			target: name,
+			next: func(m *model, input string) tea.Cmd {
+				filter, err := parseStickFilter(input)
+				if err != nil {
+					m.message = "Invalid filter: " + err.Error()
+					return nil
+				}
+				m.sticks.dataFilter = filter
+				return m.refreshStickTables()
+			},
+		}, m.sticks.dataFilter)
+		return nil, true
+	case "F":
+		m.sticks.dataFilter = ""
+		return m.refreshStickTables(), true
+	case "x":
+		if len(row) >= 1 {
+			m.askConfirmCommand(fmt.Sprintf("clear table %s key %s", name, cliEscape(row[0])))
+		}
+		return nil, true
+	case "e":
+		if len(row) < 1 || len(m.sticks.dataColumns) == 0 {
+			return nil, true
+		}
+		key := row[0]
+		current := ""
+		if m.sticks.sortColumn >= 0 {
+			current = m.sticks.dataColumns[m.sticks.sortColumn]
+		}
+		m.startTextInput(textAction{
+			label:  "Data type",
+			target: key,
+			next: func(m *model, typ string) tea.Cmd {
+				typ = strings.TrimPrefix(typ, "data.")
+				m.startNumericInput(numericAction{
+					label:  typ,
+					target: key,
+					min:    0,
+					max:    MaxStickTableData,
+					next: func(m *model, v int) tea.Cmd {
+						m.askConfirmCommand(fmt.Sprintf("set table %s key %s data.%s %d", name, cliEscape(key), typ, v))
+						return nil
+					},
+				}, m.stickValue(key, typ))
+				return nil
+			},
+		}, current)
+		return nil, true
+	}
+	return nil, false
+}
+
+// stickValue returns the current value of data type typ for key, or "".
+func (m model) stickValue(key, typ string) string {
+	for i, name := range m.sticks.dataColumns {
+		if name != typ {
+			continue
+		}
+		for _, row := range m.sticks.entries {
+			if row[0] == key {
+				return row[sticktables.FixedColumns+i]
+			}
+		}
+	}
+	return ""
+}
+
+// stickTitle describes the opened table, its data filter and sort column.
+func (m model) stickTitle() string {
+	s := m.sticks
+	if s.openName == "" {
+		return ""
+	}
+	title := fmt.Sprintf("Table %s (%d entries)", s.openName, len(s.entries))
+	if s.dataFilter != "" {
+		title += " where " + s.dataFilter
+	}
+	if s.sortColumn >= 0 && s.sortColumn < len(s.dataColumns) {
+		title += ", top " + s.dataColumns[s.sortColumn]
+	}
+	return title
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestParseStickFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "rate", input: "http_req_rate gt 100", expected: "http_req_rate gt 100"},
+		{name: "data prefix", input: " data.gpc0  eq 1 ", expected: "gpc0 eq 1"},
+		{name: "unknown operator", input: "gpc0 > 1", wantErr: true},
+		{name: "not a number", input: "gpc0 eq many", wantErr: true},
+		{name: "missing value", input: "gpc0 eq", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseStickFilter(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStickFilter(%q) error = %v; wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parseStickFilter(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStickShowCommand(t *testing.T) {
+	if cmd := stickShowCommand("http", ""); cmd != "show table http" {
+		t.Errorf("stickShowCommand() without filter = %q", cmd)
+	}
+	if cmd := stickShowCommand("http", "http_req_rate gt 100"); cmd != "show table http data.http_req_rate gt 100" {
+		t.Errorf("stickShowCommand() with filter = %q", cmd)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import "strings"
+
+// tabPickerKeys are the keys of the tab picker, in tab order: the number
+// keys for the first nine tabs, then letters.
+const tabPickerKeys = "123456789abcdefghijklmnopqrstuvwxyz"
+
+// tabPickerIndex returns the index of the tab bound to key in the picker.
+func tabPickerIndex(key string) (int, bool) {
+	if len(key) != 1 {
+		return 0, false
+	}
+	i := strings.Index(tabPickerKeys, key)
+	return i, i >= 0
+}
+
+// visibleTabs returns the range of tabs, from first to last inclusive, that
+// fits in width around the active one, which is always shown. widths are
+// the rendered widths of the tabs.
+func visibleTabs(widths []int, active, width int) (first, last int) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	if total <= width {
+		return 0, len(widths) - 1
+	}
+
+	first, last = active, active
+	used := widths[active]
+	for {
+		grown := false
+		if last+1 < len(widths) && used+widths[last+1] <= width {
+			last++
+			used += widths[last]
+			grown = true
+		}
+		if first > 0 && used+widths[first-1] <= width {
+			first--
+			used += widths[first]
+			grown = true
+		}
+		if !grown {
+			return first, last
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestVisibleTabs(t *testing.T) {
+	widths := []int{10, 10, 10, 10, 10}
+	tests := []struct {
+		name      string
+		active    int
+		width     int
+		wantFirst int
+		wantLast  int
+	}{
+		{name: "all fit", active: 3, width: 50, wantFirst: 0, wantLast: 4},
+		{name: "first tab", active: 0, width: 25, wantFirst: 0, wantLast: 1},
+		{name: "middle tab", active: 2, width: 35, wantFirst: 1, wantLast: 3},
+		{name: "last tab", active: 4, width: 30, wantFirst: 2, wantLast: 4},
+		{name: "narrower than a tab", active: 1, width: 5, wantFirst: 1, wantLast: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := visibleTabs(widths, tt.active, tt.width)
+			if first != tt.wantFirst || last != tt.wantLast {
+				t.Errorf("visibleTabs() = %d, %d; want %d, %d", first, last, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestTabPicker(t *testing.T) {
+	tabs := make([]string, int(tracesTab)+1)
+	var m tea.Model = model{tabs: tabs}
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: '0', Text: "0"})
+	if !m.(model).tabPicker {
+		t.Fatal("0 did not open the tab picker")
+	}
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if !m.(model).tabPicker {
+		t.Fatal("picker closed on a key bound to no tab")
+	}
+
+	// Tabs past the ninth are bound to letters
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if got := m.(model); got.tabPicker || got.activeTab != tracesTab {
+		t.Errorf("after h: picker %v, active tab %d; want %d", got.tabPicker, got.activeTab, tracesTab)
+	}
+}
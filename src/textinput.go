@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// textAction prompts for a free-form value, such as an address or a map
+// entry, and hands it to next once entered.
+type textAction struct {
+	label    string
+	target   string
+	optional bool // next also runs when nothing is entered
+	next     func(m *model, value string) tea.Cmd
+}
+
+// startTextInput opens the text input prompt for action, prefilled with
+// current.
+func (m *model) startTextInput(action textAction, current string) {
+	m.textMode = true
+	m.textAction = action
+	m.textInput = current
+}
+
+// askConfirmCommand asks the operator to confirm sending cmd.
+func (m *model) askConfirmCommand(cmd string) {
+	m.confirmMode = true
+	m.confirmAction = "command"
+	m.confirmCommand = cmd
+	m.confirmTracked = nil
+}
+
+// cliEscape escapes spaces so a value reaches HAProxy as a single argument.
+func cliEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, " ", `\ `)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/threads"
+)
+
+// threadList is the state of the Threads tab: the threads of the last two
+// refreshes, to spot growing prof counters.
+type threadList struct {
+	threads  []threads.Thread
+	previous []threads.Thread
+	raw      bool // show the raw dump rather than the table
+}
+
+// handleThreads stores the threads of a refresh.
+func (m *model) handleThreads(output string) {
+	m.threads = output
+	m.threadList.previous = m.threadList.threads
+	m.threadList.threads = threads.ParseThreads(output)
+	if m.activeTab == threadsTab && !m.threadList.raw {
+		m.applyFilter()
+	}
+}
+
+func (m model) threadRows() []table.Row {
+	return threads.Rows(m.threadList.threads, m.threadList.previous, m.activityList.metrics)
+}
+
+// updateThreadKeys handles the keys of the Threads tab. handled is false
+// for keys left to the common handling.
+func (m *model) updateThreadKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	if msg.String() == "v" {
+		m.threadList.raw = !m.threadList.raw
+		m.switchTab(threadsTab)
+		return nil, true
+	}
+	return nil, false
+}
+
+// threadsBadge alerts in the tab bar when HAProxy reports a stuck thread.
+func (m model) threadsBadge() string {
+	if len(threads.Stuck(m.threadList.threads)) == 0 {
+		return ""
+	}
+	return " ⚠stuck"
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+	"github.com/knowald/lazyhap/src/views/traces"
+)
+
+// traceView is the state of the Traces tab: the trace sources, the
+// settings of the opened one, the prior settings of the sources changed
+// from the tab, and the tail of a source's sink.
+type traceView struct {
+	sources  []traces.Source
+	open     string
+	settings []traces.Setting
+	saved    map[string]traces.Snapshot
+	pending  string // source of the command waiting for confirmation
+	tail     eventView
+}
+
+type tracesMsg struct {
+	sources []traces.Source
+}
+
+type traceSettingsMsg struct {
+	source   string
+	settings []traces.Setting
+}
+
+func fetchTraces(cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		return tracesMsg{sources: traces.ParseSources(execCommand(cfg, "trace"))}
+	}
+}
+
+// fetchTraceSettings fetches every setting of source with "trace <source>
+// <kind>".
+func fetchTraceSettings(cfg Config, source string) tea.Cmd {
+	return func() tea.Msg {
+		msg := traceSettingsMsg{source: source}
+		for _, kind := range traces.Kinds {
+			msg.settings = append(msg.settings, traces.ParseSetting(kind, execCommand(cfg, "trace "+source+" "+kind)))
+		}
+		return msg
+	}
+}
+
+func (m model) refreshTraces() tea.Cmd {
+	if m.traceList.open != "" {
+		return tea.Batch(fetchTraces(m.config), fetchTraceSettings(m.config, m.traceList.open))
+	}
+	return fetchTraces(m.config)
+}
+
+func (m *model) handleTraces(msg tracesMsg) {
+	m.traceList.sources = msg.sources
+	if m.activeTab == tracesTab && m.traceList.tail.stream == nil {
+		m.applyFilter()
+	}
+}
+
+func (m *model) handleTraceSettings(msg traceSettingsMsg) {
+	if msg.source != m.traceList.open {
+		return
+	}
+	m.traceList.settings = msg.settings
+	if m.activeTab == tracesTab && m.traceList.tail.stream == nil {
+		m.applyFilter()
+	}
+}
+
+// showTraces sets up the table for the sources or the opened source's
+// settings, or the viewport on the tailed sink.
+func (m *model) showTraces() {
+	if m.traceList.tail.stream != nil {
+		m.showStreamViewport(m.traceList.tail)
+		return
+	}
+	if m.traceList.open != "" {
+		m.table = traces.InitializeSettingsTable()
+	} else {
+		m.table = traces.InitializeSourcesTable()
+	}
+	m.applyTableSize()
+	m.applyFilter()
+}
+
+func (m model) traceRows() []table.Row {
+	if m.traceList.open != "" {
+		return traces.SettingRows(m.traceList.settings)
+	}
+	return traces.SourceRows(m.traceList.sources)
+}
+
+// selectedTraceSource returns the opened or selected source.
+func (m model) selectedTraceSource() string {
+	if m.traceList.open != "" {
+		return m.traceList.open
+	}
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return ""
+	}
+	return row[0]
+}
+
+// saveTraceSnapshot records the state and settings of source before it is
+// first changed, so leaving the tab restores them. Settings are only known
+// once the source is opened, so a source started from the list has them
+// recorded on its first setting change.
+func (m *model) saveTraceSnapshot(source string) {
+	if m.traceList.saved == nil {
+		m.traceList.saved = map[string]traces.Snapshot{}
+	}
+	snap, ok := m.traceList.saved[source]
+	if !ok {
+		snap = traces.Snapshot{Source: source, State: traces.StateStopped}
+		for _, s := range m.traceList.sources {
+			if s.Name == source {
+				snap.State = s.State
+			}
+		}
+	}
+	if snap.Settings == nil && source == m.traceList.open {
+		snap.Settings = m.traceList.settings
+	}
+	m.traceList.saved[source] = snap
+}
+
+// askTraceCommand asks to confirm cmd changing source. The source's prior
+// settings are recorded once confirmed, in runTraceCommand.
+func (m *model) askTraceCommand(source, cmd string) {
+	m.askConfirmCommand(cmd)
+	m.confirmAction = "trace"
+	m.traceList.pending = source
+}
+
+// runTraceCommand records the settings of the pending source, then sends
+// the confirmed command changing them.
+func (m *model) runTraceCommand() tea.Cmd {
+	m.saveTraceSnapshot(m.traceList.pending)
+	m.traceList.pending = ""
+	return runServerCommand(m.config, m.confirmCommand)
+}
+
+// changedTraceSources returns the sources changed from the tab, sorted.
+func (v traceView) changedTraceSources() []string {
+	var sources []string
+	for source := range v.saved {
+		sources = append(sources, source)
+	}
+	slices.Sort(sources)
+	return sources
+}
+
+// leaveTraces stops tailing and restores the sources changed from the tab.
+func (m *model) leaveTraces() tea.Cmd {
+	m.traceList.tail.stop()
+	sources := m.traceList.changedTraceSources()
+	if len(sources) == 0 {
+		return nil
+	}
+	var cmds []string
+	for _, source := range sources {
+		cmds = append(cmds, m.traceList.saved[source].RevertCommands()...)
+	}
+	m.traceList.saved = nil
+	return revertTraces(m.config, sources, cmds)
+}
+
+// revertTraces sends the commands restoring the trace settings of sources.
+func revertTraces(cfg Config, sources, cmds []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, cmd := range cmds {
+			execAction(cfg, cmd)
+		}
+		return commandResultMsg{command: "revert traces", reply: "Restored trace settings of " + strings.Join(sources, ", ")}
+	}
+}
+
+// changeTraceSetting prompts for a new value of the selected setting of
+// the opened source.
+func (m *model) changeTraceSetting() {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return
+	}
+	if reason := m.actionBlockedReason(levelAdmin); reason != "" {
+		m.message = "Action disabled: " + reason
+		return
+	}
+	source := m.traceList.open
+	setting, ok := traces.Lookup(m.traceList.settings, row[0])
+	if !ok {
+		return
+	}
+
+	label, prefill := "Set "+setting.Kind, setting.Value()
+	if setting.IsEventSet() {
+		label, prefill = "Event to enable (+name), disable (-name), any or none", ""
+	}
+	m.startTextInput(textAction{
+		label:  label,
+		target: source,
+		next: func(m *model, value string) tea.Cmd {
+			value = strings.TrimSpace(value)
+			name := strings.TrimLeft(value, "+-!")
+			if name == "" {
+				return nil
+			}
+			if !slices.Contains(setting.Choices, name) {
+				m.message = "Unknown " + setting.Kind + " " + name + " for " + source
+				return nil
+			}
+			m.askTraceCommand(source, "trace "+source+" "+setting.Kind+" "+value)
+			return nil
+		},
+	}, prefill)
+}
+
+// updateTraceKeys handles the keys of the Traces tab. handled is false for
+// keys left to the common handling.
+func (m *model) updateTraceKeys(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	if m.traceList.tail.stream != nil {
+		switch msg.String() {
+		case "p":
+			m.traceList.tail.togglePause()
+			m.showTraces()
+			return nil, true
+		case "esc", "backspace":
+			m.traceList.tail.stop()
+			m.viewportFilterInput = ""
+			m.showTraces()
+			return nil, true
+		}
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "S", "X":
+		source := m.selectedTraceSource()
+		if source == "" {
+			return nil, true
+		}
+		verb := "start"
+		if msg.String() == "X" {
+			verb = "stop"
+		}
+		m.askTraceCommand(source, "trace "+source+" "+verb+" now")
+		return nil, true
+	}
+
+	if m.traceList.open == "" {
+		if msg.String() == "enter" {
+			source := m.selectedTraceSource()
+			if source == "" {
+				return nil, true
+			}
+			m.traceList.open = source
+			m.traceList.settings = nil
+			m.filterInput = ""
+			m.showTraces()
+			return fetchTraceSettings(m.config, source), true
+		}
+		return nil, false
+	}
+
+	switch msg.String() {
+	case "enter":
+		m.changeTraceSetting()
+		return nil, true
+	case "t":
+		sink, _ := traces.Lookup(m.traceList.settings, "sink")
+		if ring := sink.Value(); ring != "" && ring != "none" {
+			return m.traceList.tail.follow(m.config, tracesTab, ring, true), true
+		}
+		m.message = "No sink set for " + m.traceList.open + ", set one to tail it"
+		return nil, true
+	case "esc", "backspace":
+		m.traceList.open = ""
+		m.traceList.settings = nil
+		m.filterInput = ""
+		m.showTraces()
+		return nil, true
+	}
+	return nil, false
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/knowald/lazyhap/src/views/traces"
+)
+
+func TestSaveTraceSnapshot(t *testing.T) {
+	m := model{traceList: traceView{
+		sources: []traces.Source{{Name: "h1", State: traces.StateStopped}},
+	}}
+
+	// Started from the list: only the state is known
+	m.saveTraceSnapshot("h1")
+	if snap := m.traceList.saved["h1"]; snap.State != traces.StateStopped || snap.Settings != nil {
+		t.Fatalf("snapshot from the list = %+v", snap)
+	}
+
+	// Opened later: the settings, unchanged so far, complete the snapshot
+	m.traceList.sources[0].State = traces.StateRunning
+	m.traceList.open = "h1"
+	m.traceList.settings = []traces.Setting{{Kind: "level", Current: []string{"user"}}}
+	m.saveTraceSnapshot("h1")
+	snap := m.traceList.saved["h1"]
+	if snap.State != traces.StateStopped || len(snap.Settings) != 1 {
+		t.Fatalf("completed snapshot = %+v", snap)
+	}
+
+	// Further changes keep the first recorded settings
+	m.traceList.settings = []traces.Setting{{Kind: "level", Current: []string{"developer"}}}
+	m.saveTraceSnapshot("h1")
+	if got := m.traceList.saved["h1"].Settings[0].Value(); got != "user" {
+		t.Errorf("level = %q, want user", got)
+	}
+
+	if m.leaveTraces() == nil {
+		t.Fatal("leaveTraces() returned no revert command")
+	}
+	if len(m.traceList.saved) != 0 {
+		t.Error("snapshots kept after leaving")
+	}
+	if m.leaveTraces() != nil {
+		t.Error("second leaveTraces() should have nothing to revert")
+	}
+}
+
+func TestTraceSnapshotOnConfirm(t *testing.T) {
+	var m tea.Model = model{traceList: traceView{
+		sources: []traces.Source{{Name: "h1", State: traces.StateStopped}},
+	}}
+
+	// Cancelled: the source is left alone when leaving the tab
+	mm := m.(model)
+	mm.askTraceCommand("h1", "trace h1 start now")
+	m, _ = mm.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if saved := m.(model).traceList.saved; len(saved) != 0 {
+		t.Fatalf("snapshot after cancelling = %+v", saved)
+	}
+
+	// Accepted: recorded before the command is sent
+	mm = m.(model)
+	mm.askTraceCommand("h1", "trace h1 start now")
+	m, _ = mm.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if snap, ok := m.(model).traceList.saved["h1"]; !ok || snap.State != traces.StateStopped {
+		t.Errorf("snapshot after confirming = %+v", snap)
+	}
+}
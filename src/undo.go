@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Admin state bits reported in the srv_admin_state column of
+// "show servers state".
+const (
+	adminForcedMaint = 0x01
+	adminForcedDrain = 0x08
+	adminMaint       = 0x23 // forced, inherited or configured maintenance
+	adminDrain       = 0x18 // forced or inherited drain
+)
+
+// serverRef identifies a server within a backend.
+type serverRef struct {
+	backend string
+	server  string
+}
+
+func (r serverRef) String() string {
+	return r.backend + "/" + r.server
+}
+
+// serverState is the part of a server's runtime state that actions change
+// and undo restores.
+type serverState struct {
+	serverRef
+	admin  int
+	weight int
+}
+
+// adminName maps the forced admin bits to the state accepted by
+// "set server ... state".
+func (s serverState) adminName() string {
+	switch {
+	case s.admin&adminForcedMaint != 0:
+		return "maint"
+	case s.admin&adminForcedDrain != 0:
+		return "drain"
+	default:
+		return "ready"
+	}
+}
+
+func (s serverState) String() string {
+	return fmt.Sprintf("%s %s weight %d", s.serverRef, s.adminName(), s.weight)
+}
+
+// sameAs reports whether the admin state and weight match other.
+func (s serverState) sameAs(other serverState) bool {
+	return s.adminName() == other.adminName() && s.weight == other.weight
+}
+
+// undoEntry records the servers touched by one action, with their state
+// before the action ran and right after it.
+type undoEntry struct {
+	id     int // set when pushed on the undo stack
+	action string
+	before []serverState
+	after  []serverState
+}
+
+// description says what undoing this entry will restore.
+func (e undoEntry) description() string {
+	restores := make([]string, len(e.before))
+	for i, s := range e.before {
+		restores[i] = s.String()
+	}
+	return e.action + " → restore " + strings.Join(restores, ", ")
+}
+
+// trackedActionMsg reports an action whose prior server state was recorded.
+// entry is nil when the state could not be read, in which case the action
+// still ran but cannot be undone.
+type trackedActionMsg struct {
+	commandResultMsg
+	entry *undoEntry
+}
+
+type undoResultMsg struct {
+	id      int
+	undone  bool // every command of the restore succeeded
+	message string
+}
+
+// parseServersState parses "show servers state" output, using the "#"
+// header line to locate columns.
+func parseServersState(output string) []serverState {
+	var states []serverState
+	cols := map[string]int{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			for i, name := range strings.Fields(strings.TrimPrefix(line, "#")) {
+				cols[name] = i
+			}
+			continue
+		}
+		be, okBe := cols["be_name"]
+		srv, okSrv := cols["srv_name"]
+		admin, okAdmin := cols["srv_admin_state"]
+		weight, okWeight := cols["srv_uweight"]
+		if !okBe || !okSrv || !okAdmin || !okWeight {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) <= be || len(fields) <= srv || len(fields) <= admin || len(fields) <= weight {
+			continue
+		}
+		states = append(states, serverState{
+			serverRef: serverRef{backend: fields[be], server: fields[srv]},
+			admin:     int(stringToInt(fields[admin])),
+			weight:    int(stringToInt(fields[weight])),
+		})
+	}
+
+	return states
+}
+
+// snapshotServers reads the current state of servers. ok is false when any
+// of them is missing from "show servers state".
+func snapshotServers(cfg Config, servers []serverRef) ([]serverState, bool) {
+	all := parseServersState(execCommand(cfg, "show servers state"))
+	snapshot := make([]serverState, 0, len(servers))
+	for _, ref := range servers {
+		found := false
+		for _, s := range all {
+			if s.serverRef == ref {
+				snapshot = append(snapshot, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return snapshot, true
+}
+
+// trackedAction records the state of servers, runs cmds, and returns an undo
+// entry restoring the recorded state.
+func trackedAction(cfg Config, action string, servers []serverRef, cmds ...string) tea.Cmd {
+	return func() tea.Msg {
+		before, ok := snapshotServers(cfg, servers)
+
+		var replies []string
+		for _, cmd := range cmds {
+			if reply := strings.TrimSpace(execAction(cfg, cmd)); reply != "" {
+				replies = append(replies, reply)
+			}
+		}
+
+		msg := trackedActionMsg{
+			commandResultMsg: commandResultMsg{command: action, reply: strings.Join(replies, "\n")},
+		}
+		if !ok {
+			return msg
+		}
+		after, ok := snapshotServers(cfg, servers)
+		if !ok {
+			return msg
+		}
+		msg.entry = &undoEntry{action: action, before: before, after: after}
+		return msg
+	}
+}
+
+// undoAction restores the state recorded in entry, refusing when any server
+// changed since the action ran.
+func undoAction(cfg Config, entry undoEntry) tea.Cmd {
+	return func() tea.Msg {
+		refs := make([]serverRef, len(entry.after))
+		for i, s := range entry.after {
+			refs[i] = s.serverRef
+		}
+		current, ok := snapshotServers(cfg, refs)
+		if !ok {
+			return undoResultMsg{id: entry.id, message: "Undo refused: could not read server state"}
+		}
+		for i, s := range current {
+			if !s.sameAs(entry.after[i]) {
+				return undoResultMsg{id: entry.id, message: fmt.Sprintf("Undo refused: %s changed since (now %s, weight %d)", s.serverRef, s.adminName(), s.weight)}
+			}
+		}
+
+		// HAProxy answers these commands with nothing on success
+		var failures []string
+		for _, s := range entry.before {
+			for _, cmd := range []string{
+				fmt.Sprintf("set server %s state %s", s.serverRef, s.adminName()),
+				fmt.Sprintf("set server %s weight %d", s.serverRef, s.weight),
+			} {
+				if reply := strings.TrimSpace(execAction(cfg, cmd)); reply != "" {
+					failures = append(failures, cmd+": "+firstLine(reply))
+				}
+			}
+		}
+		if len(failures) > 0 {
+			return undoResultMsg{id: entry.id, message: "Undo of " + entry.action + " failed: " + strings.Join(failures, "; ")}
+		}
+		return undoResultMsg{id: entry.id, undone: true, message: "Undone: " + entry.action}
+	}
+}
+
+// dropUndo removes the entry id from the undo stack once it was undone.
+func (m *model) dropUndo(id int) {
+	for i, entry := range m.undoStack {
+		if entry.id == id {
+			m.undoStack = append(m.undoStack[:i], m.undoStack[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+)
+
+const serversStateOutput = `1
+# be_id be_name srv_id srv_name srv_addr srv_op_state srv_admin_state srv_uweight srv_iweight srv_time_since_last_change srv_check_status srv_check_result srv_check_health srv_check_state srv_agent_state bk_f_forced_id srv_f_forced_id srv_fqdn srv_port srvrecord
+3 web 1 web-01 172.18.0.3 2 0 100 100 6 6 3 4 6 0 0 0 - 80 -
+3 web 2 web-02 172.18.0.4 0 1 100 100 6 6 3 4 6 0 0 0 - 80 -
+3 web 3 web-03 172.18.0.5 2 8 25 100 6 6 3 4 6 0 0 0 - 80 -
+`
+
+func TestParseServersState(t *testing.T) {
+	states := parseServersState(serversStateOutput)
+	if len(states) != 3 {
+		t.Fatalf("parseServersState() returned %d servers; want 3", len(states))
+	}
+
+	tests := []struct {
+		ref    serverRef
+		admin  string
+		weight int
+	}{
+		{serverRef{"web", "web-01"}, "ready", 100},
+		{serverRef{"web", "web-02"}, "maint", 100},
+		{serverRef{"web", "web-03"}, "drain", 25},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.ref.String(), func(t *testing.T) {
+			s := states[i]
+			if s.serverRef != tt.ref {
+				t.Errorf("server = %s; want %s", s.serverRef, tt.ref)
+			}
+			if s.adminName() != tt.admin {
+				t.Errorf("adminName() = %q; want %q", s.adminName(), tt.admin)
+			}
+			if s.weight != tt.weight {
+				t.Errorf("weight = %d; want %d", s.weight, tt.weight)
+			}
+		})
+	}
+}
+
+func TestParseServersStateWithoutHeader(t *testing.T) {
+	states := parseServersState("1\n3 web 1 web-01 172.18.0.3 2 0 100 100\n")
+	if len(states) != 0 {
+		t.Errorf("parseServersState() without header returned %d servers; want 0", len(states))
+	}
+}
+
+func TestUndoAction(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	ref := serverRef{"web", "web-01"}
+	entry := undoEntry{
+		id:     1,
+		action: "disable server web/web-01",
+		before: []serverState{{serverRef: ref, admin: 0, weight: 100}},
+		after:  []serverState{{serverRef: ref, admin: 0, weight: 100}}, // web-01 as in serversStateOutput
+	}
+
+	tests := []struct {
+		name    string
+		entry   undoEntry
+		replies map[string]string
+		undone  bool
+	}{
+		{name: "restored", entry: entry, undone: true},
+		{
+			name:    "failed restore",
+			entry:   entry,
+			replies: map[string]string{"set server web/web-01 weight 100": "Backend is using a static LB algorithm.\n"},
+		},
+		{
+			name: "changed since",
+			entry: undoEntry{id: 1, action: entry.action, before: entry.before,
+				after: []serverState{{serverRef: ref, admin: adminForcedMaint, weight: 100}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replies := map[string]string{"show servers state": serversStateOutput}
+			for cmd, reply := range tt.replies {
+				replies[cmd] = reply
+			}
+			socket, _ := fakeSocket(t, replies)
+
+			msg := undoAction(Config{socketPath: socket}, tt.entry)().(undoResultMsg)
+			if msg.undone != tt.undone || msg.id != 1 {
+				t.Errorf("undoAction() = %+v; want undone %v", msg, tt.undone)
+			}
+		})
+	}
+}
+
+func TestDropUndo(t *testing.T) {
+	m := model{undoStack: []undoEntry{{id: 1}, {id: 2}, {id: 3}}}
+	m.dropUndo(2)
+	if len(m.undoStack) != 2 || m.undoStack[0].id != 1 || m.undoStack[1].id != 3 {
+		t.Errorf("undo stack = %+v; want entries 1 and 3", m.undoStack)
+	}
+}
@@ -6,16 +6,24 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/knowald/lazyhap/src/views/acl"
 	"github.com/knowald/lazyhap/src/views/activity"
+	"github.com/knowald/lazyhap/src/views/audit"
+	"github.com/knowald/lazyhap/src/views/cafiles"
 	"github.com/knowald/lazyhap/src/views/certs"
+	"github.com/knowald/lazyhap/src/views/crtlists"
 	"github.com/knowald/lazyhap/src/views/error"
 	"github.com/knowald/lazyhap/src/views/events"
 	"github.com/knowald/lazyhap/src/views/help"
 	"github.com/knowald/lazyhap/src/views/info"
+	"github.com/knowald/lazyhap/src/views/maps"
 	"github.com/knowald/lazyhap/src/views/pools"
+	"github.com/knowald/lazyhap/src/views/rolling"
 	"github.com/knowald/lazyhap/src/views/sessions"
 	"github.com/knowald/lazyhap/src/views/stats"
+	"github.com/knowald/lazyhap/src/views/sticktables"
 	"github.com/knowald/lazyhap/src/views/threads"
+	"github.com/knowald/lazyhap/src/views/traces"
 )
 
 func (m model) View() tea.View {
@@ -50,6 +58,22 @@ func (m model) View() tea.View {
 			activity.RenderTab(&sb, m, baseStyle)
 		case eventsTab:
 			events.RenderTab(&sb, m, baseStyle)
+		case auditTab:
+			audit.RenderTab(&sb, m, baseStyle)
+		case rollingTab:
+			rolling.RenderTab(&sb, m, baseStyle)
+		case mapsTab:
+			maps.RenderTab(&sb, m, baseStyle)
+		case aclTab:
+			acl.RenderTab(&sb, m, baseStyle)
+		case stickTablesTab:
+			sticktables.RenderTab(&sb, m, baseStyle)
+		case crtListsTab:
+			crtlists.RenderTab(&sb, m, baseStyle)
+		case caFilesTab:
+			cafiles.RenderTab(&sb, m, baseStyle)
+		case tracesTab:
+			traces.RenderTab(&sb, m, baseStyle)
 		}
 
 		content = sb.String()
@@ -62,16 +86,74 @@ func (m model) View() tea.View {
 
 // Navigation header
 func renderTabBar(sb *strings.Builder, m model) {
+	if m.tabPicker {
+		renderTabPicker(sb, m)
+		return
+	}
+
 	renderedTabs := make([]string, len(m.tabs))
+	widths := make([]int, len(m.tabs))
 	for i, t := range m.tabs {
+		switch tab(i) {
+		case certsTab:
+			t += m.certsBadge()
+		case threadsTab:
+			t += m.threadsBadge()
+		}
 		if i == int(m.activeTab) {
 			renderedTabs[i] = activeTabStyle.Render(t)
 		} else {
 			renderedTabs[i] = tabStyle.Render(t)
 		}
+		widths[i] = lipgloss.Width(renderedTabs[i])
+	}
+
+	// On narrow terminals, show the tabs around the active one that fit in
+	// at least half the width, with room for the markers of hidden tabs,
+	// and cut the status line short
+	status := renderLastUpdatedTime(m)
+	first, last := 0, len(renderedTabs)-1
+	if m.width > 0 {
+		avail := max(m.width-lipgloss.Width(status), m.width/2) - 4
+		first, last = visibleTabs(widths, int(m.activeTab), max(avail, 1))
+	}
+	shown := renderedTabs[first : last+1]
+	if first > 0 {
+		shown = append([]string{tabStyle.Render("‹")}, shown...)
+	}
+	if last < len(renderedTabs)-1 {
+		shown = append(shown, tabStyle.Render("›"))
+	}
+	bar := lipgloss.JoinHorizontal(lipgloss.Left, shown...)
+	sb.WriteString(bar)
+	if m.width > 0 {
+		status = lipgloss.NewStyle().MaxWidth(max(m.width-lipgloss.Width(bar), 0)).Render(status)
+	}
+	sb.WriteString(status)
+}
+
+// renderTabPicker lists every tab with the key jumping to it, wrapped to
+// the terminal width.
+func renderTabPicker(sb *strings.Builder, m model) {
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	line, lineWidth := "", 0
+	for i, t := range m.tabs {
+		if i >= len(tabPickerKeys) {
+			break
+		}
+		entry := tabStyle.Render(keyStyle.Render(tabPickerKeys[i:i+1]) + " " + t)
+		if i == int(m.activeTab) {
+			entry = activeTabStyle.Render(tabPickerKeys[i:i+1] + " " + t)
+		}
+		if w := lipgloss.Width(entry); m.width > 0 && lineWidth > 0 && lineWidth+w > m.width {
+			sb.WriteString(line + "\n")
+			line, lineWidth = "", 0
+		}
+		line += entry
+		lineWidth += lipgloss.Width(entry)
 	}
-	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Left, renderedTabs...))
-	sb.WriteString(renderLastUpdatedTime(m))
+	sb.WriteString(line)
+	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).MarginLeft(2).Render("Go to tab (esc: cancel)"))
 }
 
 // Timestamp, last updated
@@ -85,6 +167,11 @@ func renderLastUpdatedTime(m model) string {
 		status = dot + " " + truncate(m.config.socketPath, 30) + "  "
 	}
 
+	if badge := m.accessBadge(); badge != "" {
+		badgeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+		status += badgeStyle.Render("["+badge+"]") + "  "
+	}
+
 	timestamp := timeStyle.Render(fmt.Sprintf("Updated: %s", m.lastFetch.Format("15:04:05")))
 	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241")).MarginLeft(2)
 	hint := hintStyle.Render("Press ? for help")
@@ -0,0 +1,77 @@
+package acl
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// InitializeListTable builds the table listing loaded ACLs.
+func InitializeListTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "ID", Width: 5},
+		{Title: "File", Width: 40},
+		{Title: "Entries", Width: 8},
+		{Title: "Description", Width: 90},
+	})
+}
+
+// InitializeEntriesTable builds the table showing the patterns of one ACL.
+func InitializeEntriesTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Pattern", Width: 100},
+	})
+}
+
+// InitializeDiffTable builds the table comparing the loaded patterns with
+// the ones from a local file.
+func InitializeDiffTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Change", Width: 7},
+		{Title: "Pattern", Width: 100},
+	})
+}
+
+// ParseEntries parses "show acl <acl>" output, where each line holds the
+// entry's pointer and pattern, into Pattern rows.
+func ParseEntries(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !strings.HasPrefix(line, "0x") {
+			continue
+		}
+		_, pattern, _ := strings.Cut(line, " ")
+		rows = append(rows, table.Row{strings.TrimSpace(pattern)})
+	}
+	return rows
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,50 @@
+package acl
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []table.Row
+	}{
+		{
+			name:  "patterns",
+			input: "0x55d1d8e5a7a0 10.0.0.0/8\n0x55d1d8e5a820 192.168.1.12\n",
+			expected: []table.Row{
+				{"10.0.0.0/8"},
+				{"192.168.1.12"},
+			},
+		},
+		{
+			name:  "pattern with spaces",
+			input: "0x55d1d8e5a7a0 Mozilla/5.0 (compatible; BadBot)\n",
+			expected: []table.Row{
+				{"Mozilla/5.0 (compatible; BadBot)"},
+			},
+		},
+		{
+			name:     "unknown acl",
+			input:    "Unknown ACL identifier. Please use #<id> or <file>.\n",
+			expected: []table.Row{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseEntries(tt.input)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("returned %d rows; want %d rows: %q", len(result), len(tt.expected), result)
+			}
+			for i := range result {
+				if len(result[i]) != 1 || result[i][0] != tt.expected[i][0] {
+					t.Errorf("Row %d = %q; want %q", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -9,27 +9,64 @@ import (
 )
 
 type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
 	ActivityView() string
+	RawActivity() bool
+	ActivityMode() (absolute bool, threadOrder, sort string)
+	ImbalancedMetrics() []string
 	GetViewport() viewport.Model
 	ViewportFilterMode() bool
 	ViewportFilterInput() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	content := colorize.ColorizeActivityOutput(m.ActivityView())
-	viewport.SetContent(content)
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	raw := m.RawActivity()
+	if raw {
+		viewport := m.GetViewport()
+		content := colorize.ColorizeActivityOutput(m.ActivityView())
+		viewport.SetContent(content)
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	} else {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	}
 	sb.WriteString("\n")
 
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 	if m.ViewportFilterMode() {
-		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
-	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		return
+	}
+	if m.FilterMode() {
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+	if raw {
+		sb.WriteString(hintStyle.Render("j/k: scroll  v: matrix  /: filter  ?: help"))
+		return
+	}
+
+	if imbalanced := m.ImbalancedMetrics(); len(imbalanced) > 0 {
+		alertStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(alertStyle.Render("Imbalanced: " + strings.Join(imbalanced, ", ")))
+		sb.WriteString("  ")
+	}
+	absolute, threadOrder, sort := m.ActivityMode()
+	mode := "Per-thread deltas"
+	if absolute {
+		mode = "Per-thread totals"
+	}
+	if threadOrder != "" {
+		mode += ", threads by " + threadOrder
+	}
+	if sort != "" {
+		mode += ", sorted by " + sort
 	}
+	sb.WriteString(hintStyle.Render(mode + "  d: deltas/totals  enter: order threads by metric  s: sort  v: raw"))
 }
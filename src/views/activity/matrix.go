@@ -0,0 +1,78 @@
+package activity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Metric is a "show activity" counter with its per-thread values.
+type Metric struct {
+	Name    string
+	Total   uint64
+	Threads []uint64
+}
+
+// ParseMatrix parses the per-thread counters of "show activity", printed
+// as "loops: 1240 [ 620 620 ]", or as "loops: 620 620" by older versions.
+// Lines that are not counters, such as "date_now", are skipped.
+func ParseMatrix(output string) []Metric {
+	var metrics []Metric
+	for _, line := range strings.Split(output, "\n") {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "thread_id" {
+			// The calling thread, not a counter
+			continue
+		}
+		total, values, bracketed := strings.Cut(rest, "[")
+		if bracketed {
+			values = strings.TrimSuffix(strings.TrimSpace(values), "]")
+		} else {
+			values, total = total, ""
+		}
+
+		threads, ok := parseCounts(values)
+		if !ok || len(threads) == 0 {
+			continue
+		}
+		m := Metric{Name: name, Threads: threads}
+		if bracketed {
+			n, err := strconv.ParseUint(strings.TrimSpace(total), 10, 64)
+			if err != nil {
+				continue
+			}
+			m.Total = n
+		} else {
+			for _, v := range threads {
+				m.Total += v
+			}
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+// Lookup returns the metric called name.
+func Lookup(metrics []Metric, name string) (Metric, bool) {
+	for _, m := range metrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+func parseCounts(s string) ([]uint64, bool) {
+	var counts []uint64
+	for _, field := range strings.Fields(s) {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		counts = append(counts, n)
+	}
+	return counts, true
+}
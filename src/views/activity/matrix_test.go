@@ -0,0 +1,45 @@
+package activity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Metric
+	}{
+		{
+			name: "totals and per-thread values",
+			input: "thread_id: 1 (1..2)\n" +
+				"date_now: 1621339887.479426\n" +
+				"ctxsw: 1234 [ 600 634 ]\n" +
+				"loops: 1240 [ 620 620 ]\n" +
+				"poll_io: 300 [ 240 60 ]\n",
+			expected: []Metric{
+				{Name: "ctxsw", Total: 1234, Threads: []uint64{600, 634}},
+				{Name: "loops", Total: 1240, Threads: []uint64{620, 620}},
+				{Name: "poll_io", Total: 300, Threads: []uint64{240, 60}},
+			},
+		},
+		{
+			name:  "older format without totals",
+			input: "thread_id: 0\nloops: 620 620\nwake_tasks: 10 30\n",
+			expected: []Metric{
+				{Name: "loops", Total: 1240, Threads: []uint64{620, 620}},
+				{Name: "wake_tasks", Total: 40, Threads: []uint64{10, 30}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseMatrix(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseMatrix() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
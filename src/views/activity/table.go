@@ -0,0 +1,206 @@
+package activity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// A thread is imbalanced on a metric when it does at least ImbalanceFactor
+// times its fair share of the metric's events in an interval, provided the
+// interval saw at least ImbalanceMinEvents events.
+const (
+	ImbalanceFactor    = 1.5
+	ImbalanceMinEvents = 100
+)
+
+// gauges are the metrics reporting a current level rather than a count, for
+// which a delta is meaningless.
+var gauges = map[string]bool{
+	"avg_loop_us":  true,
+	"accq_ring":    true,
+	"cpust_ms_1s":  true,
+	"cpust_ms_15s": true,
+	"avg_cpu_pct":  true,
+}
+
+// InitializeTable builds the metric × thread table, with the thread columns
+// in the given order of thread indexes.
+func InitializeTable(order []int) table.Model {
+	columns := []table.Column{
+		{Title: "Metric", Width: 16},
+		{Title: "Total", Width: 12},
+		{Title: "Δ", Width: 10},
+		{Title: "Busiest", Width: 10},
+	}
+	for _, i := range order {
+		columns = append(columns, table.Column{Title: "T" + strconv.Itoa(i+1), Width: 9})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// Threads returns the number of threads the metrics report.
+func Threads(metrics []Metric) int {
+	n := 0
+	for _, m := range metrics {
+		n = max(n, len(m.Threads))
+	}
+	return n
+}
+
+// Delta returns the per-thread increase of m since previous. Gauges and
+// metrics without a previous sample return nil; counters that went down,
+// as after a reload, count from zero.
+func Delta(m Metric, previous []Metric) []uint64 {
+	p, ok := Lookup(previous, m.Name)
+	if !ok || gauges[m.Name] {
+		return nil
+	}
+	delta := make([]uint64, len(m.Threads))
+	for i, v := range m.Threads {
+		if i < len(p.Threads) && v >= p.Threads[i] {
+			delta[i] = v - p.Threads[i]
+		} else {
+			delta[i] = v
+		}
+	}
+	return delta
+}
+
+// Busiest returns the thread doing the largest share of values, its share,
+// and whether that share makes the thread imbalanced.
+func Busiest(values []uint64) (thread int, share float64, imbalanced bool) {
+	var total uint64
+	for i, v := range values {
+		total += v
+		if v > values[thread] {
+			thread = i
+		}
+	}
+	if total == 0 || len(values) < 2 {
+		return thread, 0, false
+	}
+	share = float64(values[thread]) / float64(total)
+	fair := 1 / float64(len(values))
+	return thread, share, total >= ImbalanceMinEvents && share >= ImbalanceFactor*fair
+}
+
+// Imbalanced returns the metrics on which a thread did an imbalanced share
+// of the events since previous, as "poll_io (T3 80%)".
+func Imbalanced(metrics, previous []Metric) []string {
+	var names []string
+	for _, m := range metrics {
+		delta := Delta(m, previous)
+		if delta == nil {
+			continue
+		}
+		if thread, share, imbalanced := Busiest(delta); imbalanced {
+			names = append(names, fmt.Sprintf("%s (T%d %.0f%%)", m.Name, thread+1, share*100))
+		}
+	}
+	return names
+}
+
+// ThreadOrder orders thread indexes by the delta of the named metric,
+// busiest first, or by index when name is empty or unknown.
+func ThreadOrder(metrics, previous []Metric, name string) []int {
+	order := make([]int, Threads(metrics))
+	for i := range order {
+		order[i] = i
+	}
+	m, ok := Lookup(metrics, name)
+	if !ok {
+		return order
+	}
+	values := Delta(m, previous)
+	if values == nil {
+		values = m.Threads
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return value(values, order[a]) > value(values, order[b])
+	})
+	return order
+}
+
+// Rows converts metrics to Metric, Total, Δ and Busiest rows followed by a
+// value per thread in order: the delta since previous, or the absolute
+// value when absolute is set or for gauges. The busiest thread of an
+// imbalanced metric is shown in red.
+func Rows(metrics, previous []Metric, order []int, absolute bool) []table.Row {
+	alert := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	rows := make([]table.Row, 0, len(metrics))
+	for _, m := range metrics {
+		delta := Delta(m, previous)
+		values := delta
+		if absolute || values == nil {
+			values = m.Threads
+		}
+
+		sum, busiest := "", ""
+		imbalancedThread := -1
+		if delta != nil {
+			var total uint64
+			for _, v := range delta {
+				total += v
+			}
+			sum = strconv.FormatUint(total, 10)
+			thread, share, imbalanced := Busiest(delta)
+			if share > 0 {
+				busiest = fmt.Sprintf("T%d %.0f%%", thread+1, share*100)
+			}
+			if imbalanced {
+				busiest = alert.Render(busiest)
+				imbalancedThread = thread
+			}
+		}
+
+		row := table.Row{m.Name, strconv.FormatUint(m.Total, 10), sum, busiest}
+		for _, i := range order {
+			cell := ""
+			if i < len(values) {
+				cell = strconv.FormatUint(values[i], 10)
+			}
+			if i == imbalancedThread {
+				cell = alert.Render(cell)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func value(values []uint64, i int) uint64 {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,96 @@
+package activity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDelta(t *testing.T) {
+	previous := []Metric{
+		{Name: "poll_io", Threads: []uint64{100, 50}},
+		{Name: "avg_loop_us", Threads: []uint64{10, 12}},
+	}
+	tests := []struct {
+		name     string
+		metric   Metric
+		expected []uint64
+	}{
+		{"counter", Metric{Name: "poll_io", Threads: []uint64{180, 70}}, []uint64{80, 20}},
+		{"counter reset", Metric{Name: "poll_io", Threads: []uint64{30, 70}}, []uint64{30, 20}},
+		{"gauge", Metric{Name: "avg_loop_us", Threads: []uint64{11, 9}}, nil},
+		{"no previous sample", Metric{Name: "loops", Threads: []uint64{5, 5}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Delta(tt.metric, previous); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Delta() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBusiest(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []uint64
+		thread     int
+		imbalanced bool
+	}{
+		{"one thread doing 80%", []uint64{20, 80}, 1, true},
+		{"balanced", []uint64{55, 45}, 0, false},
+		{"too few events", []uint64{1, 9}, 1, false},
+		{"four threads", []uint64{40, 20, 20, 20}, 0, true},
+		{"single thread", []uint64{500}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thread, _, imbalanced := Busiest(tt.values)
+			if thread != tt.thread || imbalanced != tt.imbalanced {
+				t.Errorf("Busiest() = %d, %v, want %d, %v", thread, imbalanced, tt.thread, tt.imbalanced)
+			}
+		})
+	}
+}
+
+func TestThreadOrder(t *testing.T) {
+	previous := []Metric{{Name: "poll_io", Threads: []uint64{0, 0, 0}}}
+	metrics := []Metric{{Name: "poll_io", Threads: []uint64{10, 30, 20}}}
+
+	if result := ThreadOrder(metrics, previous, "poll_io"); !reflect.DeepEqual(result, []int{1, 2, 0}) {
+		t.Errorf("ThreadOrder(poll_io) = %v", result)
+	}
+	if result := ThreadOrder(metrics, previous, ""); !reflect.DeepEqual(result, []int{0, 1, 2}) {
+		t.Errorf("ThreadOrder() = %v", result)
+	}
+}
+
+func TestRows(t *testing.T) {
+	previous := []Metric{{Name: "loops", Total: 200, Threads: []uint64{100, 100}}}
+	metrics := []Metric{{Name: "loops", Total: 260, Threads: []uint64{130, 130}}}
+
+	rows := Rows(metrics, previous, []int{1, 0}, false)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	expected := []string{"loops", "260", "60", "T1 50%", "30", "30"}
+	if !reflect.DeepEqual([]string(rows[0]), expected) {
+		t.Errorf("Rows() = %v, want %v", rows[0], expected)
+	}
+
+	rows = Rows(metrics, previous, []int{0, 1}, true)
+	if rows[0][4] != "130" {
+		t.Errorf("absolute value = %q, want 130", rows[0][4])
+	}
+}
+
+func TestImbalanced(t *testing.T) {
+	previous := []Metric{{Name: "poll_io", Threads: []uint64{0, 0}}, {Name: "loops", Threads: []uint64{0, 0}}}
+	metrics := []Metric{{Name: "poll_io", Threads: []uint64{400, 100}}, {Name: "loops", Threads: []uint64{300, 300}}}
+
+	expected := []string{"poll_io (T1 80%)"}
+	if result := Imbalanced(metrics, previous); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Imbalanced() = %v, want %v", result, expected)
+	}
+}
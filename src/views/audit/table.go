@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Time", Width: 19},
+		{Title: "User", Width: 10},
+		{Title: "Instance", Width: 28},
+		{Title: "Command", Width: 50},
+		{Title: "Result", Width: 6},
+		{Title: "Reply", Width: 40},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,63 @@
+package cafiles
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	OpenCAFile() string
+	CATransactions() []string
+	StaleCAFiles() int
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	sb.WriteString(baseStyle.Render(m.TableView()))
+	sb.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+		return
+	} else if m.TextInputMode() {
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: next  esc: cancel)"))
+		return
+	} else if m.FilterMode() {
+		sb.WriteString(inputStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+
+	if transactions := m.CATransactions(); len(transactions) > 0 {
+		sb.WriteString(warnStyle.Render("Uncommitted transaction: " + strings.Join(transactions, ", ")))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("C: commit  A: abort  "))
+	}
+	if open := m.OpenCAFile(); open != "" {
+		sb.WriteString(inputStyle.Render(open))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("U: update from file  esc: back  /: filter  r: reload"))
+		return
+	}
+	if stale := m.StaleCAFiles(); stale > 0 {
+		sb.WriteString(warnStyle.Render(fmt.Sprintf("%d stale or expired", stale)))
+		sb.WriteString("  ")
+	}
+	sb.WriteString(hintStyle.Render("enter: open  U: update from file  /: filter  r: reload  ?: help"))
+}
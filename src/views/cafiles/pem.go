@@ -0,0 +1,48 @@
+package cafiles
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/knowald/lazyhap/src/views/certs"
+)
+
+// ParsePEM reads a local CA or CRL file into the entries HAProxy would
+// report once it is loaded.
+func ParsePEM(kind, name string, data []byte) (File, error) {
+	f := File{Kind: kind, Name: name}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch {
+		case kind == KindCA && block.Type == "CERTIFICATE":
+			c, _, err := certs.ParsePEM(name, pem.EncodeToMemory(block))
+			if err != nil {
+				return File{}, err
+			}
+			f.Entries = append(f.Entries, Entry{Subject: c.Subject, Issuer: c.Issuer, NotAfter: c.NotAfter, Fingerprint: c.Fingerprint})
+		case kind == KindCRL && block.Type == "X509 CRL":
+			crl, err := x509.ParseRevocationList(block.Bytes)
+			if err != nil {
+				return File{}, fmt.Errorf("invalid CRL: %w", err)
+			}
+			f.Entries = append(f.Entries, Entry{
+				Issuer:     certs.OneLineName(crl.Issuer),
+				LastUpdate: crl.ThisUpdate,
+				NextUpdate: crl.NextUpdate,
+				Revoked:    len(crl.RevokedCertificateEntries),
+			})
+		}
+	}
+	if len(f.Entries) == 0 {
+		if kind == KindCRL {
+			return File{}, fmt.Errorf("no CRL found")
+		}
+		return File{}, fmt.Errorf("no certificate found")
+	}
+	return f, nil
+}
@@ -0,0 +1,75 @@
+package cafiles
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParsePEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Client CA"},
+		NotBefore:             now.AddDate(-1, 0, 0),
+		NotAfter:              now.AddDate(5, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, ca, ca, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	issuer, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(7),
+		ThisUpdate: now.AddDate(0, 0, -8),
+		NextUpdate: now.AddDate(0, 0, -1),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(1008), RevocationTime: now.AddDate(0, 0, -9)},
+		},
+	}, issuer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crlPEM := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER})
+
+	caFile, err := ParsePEM(KindCA, "ca.pem", caPEM)
+	if err != nil {
+		t.Fatalf("ParsePEM() CA error: %v", err)
+	}
+	if len(caFile.Entries) != 1 || caFile.Entries[0].Subject != "/CN=Client CA" || caFile.Stale(now) {
+		t.Errorf("ParsePEM() CA = %+v", caFile)
+	}
+
+	crlFile, err := ParsePEM(KindCRL, "crl.pem", crlPEM)
+	if err != nil {
+		t.Fatalf("ParsePEM() CRL error: %v", err)
+	}
+	if len(crlFile.Entries) != 1 || crlFile.Entries[0].Revoked != 1 || crlFile.Entries[0].Issuer != "/CN=Client CA" {
+		t.Errorf("ParsePEM() CRL = %+v", crlFile)
+	}
+	if !crlFile.Stale(now) {
+		t.Errorf("Stale() of a CRL past its next update = false; want true")
+	}
+
+	if _, err := ParsePEM(KindCRL, "ca.pem", caPEM); err == nil {
+		t.Errorf("ParsePEM() of a CA file as CRL returned no error")
+	}
+}
@@ -0,0 +1,249 @@
+package cafiles
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+	"github.com/knowald/lazyhap/src/views/certs"
+)
+
+const defaultTableHeight = 20
+
+// Kinds of files, as named in the "ssl ca-file" and "ssl crl-file"
+// commands.
+const (
+	KindCA  = "ca-file"
+	KindCRL = "crl-file"
+)
+
+// File is a CA or CRL file as reported by "show ssl ca-file <name>" or
+// "show ssl crl-file <name>".
+type File struct {
+	Kind    string
+	Name    string
+	Status  string
+	Entries []Entry
+}
+
+// Entry is a CA certificate or a CRL of a file.
+type Entry struct {
+	Subject     string
+	Issuer      string
+	NotAfter    time.Time // CA certificates
+	Fingerprint string    // CA certificates, SHA1
+	LastUpdate  time.Time // CRLs
+	NextUpdate  time.Time // CRLs
+	Revoked     int       // CRLs
+}
+
+// InitializeListTable builds the table listing CA and CRL files.
+func InitializeListTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Type", Width: 5},
+		{Title: "File", Width: 50},
+		{Title: "Entries", Width: 8},
+		{Title: "Expires / Next Update", Width: 22},
+		{Title: "Status", Width: 12},
+	})
+}
+
+// InitializeEntriesTable builds the table showing the entries of a file of
+// the given kind.
+func InitializeEntriesTable(kind string) table.Model {
+	if kind == KindCRL {
+		return newTable([]table.Column{
+			{Title: "#", Width: 4},
+			{Title: "Issuer", Width: 40},
+			{Title: "Last Update", Width: 12},
+			{Title: "Next Update", Width: 22},
+			{Title: "Revoked", Width: 8},
+		})
+	}
+	return newTable([]table.Column{
+		{Title: "#", Width: 4},
+		{Title: "Subject", Width: 40},
+		{Title: "Issuer", Width: 30},
+		{Title: "Not After", Width: 22},
+		{Title: "SHA1 Fingerprint", Width: 42},
+	})
+}
+
+// ParseList parses "show ssl ca-file" or "show ssl crl-file" output into
+// file names. Names of files in an uncommitted transaction, prefixed with
+// "*", are returned separately. CA files are followed by their count, as in
+// "/etc/haproxy/ca.pem - 2 certificate(s)".
+func ParseList(output string) (names, transactions []string) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " - "); i > 0 {
+			line = line[:i]
+		}
+		if name, ok := strings.CutPrefix(line, "*"); ok {
+			transactions = append(transactions, name)
+		} else {
+			names = append(names, line)
+		}
+	}
+	return names, transactions
+}
+
+// ParseFile parses the details of a CA or CRL file, made of one
+// "Certificate #N:" or "Certificate Revocation List #N:" section per entry.
+func ParseFile(kind, name, output string) File {
+	f := File{Kind: kind, Name: name}
+	var sections []string
+	current := -1
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "Certificate #") || strings.HasPrefix(trimmed, "Certificate Revocation List #") {
+			sections = append(sections, "")
+			current++
+			continue
+		}
+		if current < 0 {
+			if value, ok := strings.CutPrefix(trimmed, "Status:"); ok {
+				f.Status = strings.TrimSpace(value)
+			}
+			continue
+		}
+		sections[current] += line + "\n"
+	}
+
+	for _, section := range sections {
+		if kind == KindCRL {
+			f.Entries = append(f.Entries, parseCRL(section))
+			continue
+		}
+		c := certs.ParseCert(name, section)
+		f.Entries = append(f.Entries, Entry{Subject: c.Subject, Issuer: c.Issuer, NotAfter: c.NotAfter, Fingerprint: c.Fingerprint})
+	}
+	return f
+}
+
+// parseCRL parses a "Certificate Revocation List #N:" section.
+func parseCRL(section string) Entry {
+	var e Entry
+	for _, line := range strings.Split(section, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Issuer":
+			e.Issuer = value
+		case "Last Update":
+			e.LastUpdate = certs.ParseTime(value)
+		case "Next Update":
+			e.NextUpdate = certs.ParseTime(value)
+		case "Serial Number":
+			e.Revoked++
+		}
+	}
+	return e
+}
+
+// Deadline returns the earliest expiry of the CA certificates, or the
+// earliest next update of the CRLs.
+func (f File) Deadline() time.Time {
+	var deadline time.Time
+	for _, e := range f.Entries {
+		t := e.NotAfter
+		if f.Kind == KindCRL {
+			t = e.NextUpdate
+		}
+		if !t.IsZero() && (deadline.IsZero() || t.Before(deadline)) {
+			deadline = t
+		}
+	}
+	return deadline
+}
+
+// Stale reports whether a CRL of f is past its next update, or a CA
+// certificate of f has expired.
+func (f File) Stale(now time.Time) bool {
+	deadline := f.Deadline()
+	return !deadline.IsZero() && deadline.Before(now)
+}
+
+// ListRows converts files to Type, File, Entries, Expires / Next Update and
+// Status rows, with stale files in red.
+func ListRows(files []File, now time.Time) []table.Row {
+	rows := make([]table.Row, 0, len(files))
+	for _, f := range files {
+		kind := "CA"
+		if f.Kind == KindCRL {
+			kind = "CRL"
+		}
+		rows = append(rows, table.Row{kind, f.Name, fmt.Sprint(len(f.Entries)), deadline(f.Deadline(), f.Kind, now), f.Status})
+	}
+	return rows
+}
+
+// EntryRows converts the entries of f to table rows.
+func EntryRows(f File, now time.Time) []table.Row {
+	rows := make([]table.Row, 0, len(f.Entries))
+	for i, e := range f.Entries {
+		index := fmt.Sprint(i + 1)
+		if f.Kind == KindCRL {
+			rows = append(rows, table.Row{index, certs.CommonName(e.Issuer), formatDate(e.LastUpdate), deadline(e.NextUpdate, f.Kind, now), fmt.Sprint(e.Revoked)})
+			continue
+		}
+		rows = append(rows, table.Row{index, certs.CommonName(e.Subject), certs.CommonName(e.Issuer), deadline(e.NotAfter, f.Kind, now), e.Fingerprint})
+	}
+	return rows
+}
+
+// deadline formats an expiry or next update date, flagged in red once past.
+func deadline(t time.Time, kind string, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if !t.Before(now) {
+		return formatDate(t)
+	}
+	label := " (expired)"
+	if kind == KindCRL {
+		label = " (stale)"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render(formatDate(t) + label)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,81 @@
+package cafiles
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	input := "# filename\n" +
+		"/etc/haproxy/ca.pem - 2 certificate(s)\n" +
+		"*/etc/haproxy/client-ca.pem - 1 certificate(s)\n" +
+		"/etc/haproxy/client-ca.pem - 1 certificate(s)\n"
+
+	names, transactions := ParseList(input)
+	if want := []string{"/etc/haproxy/ca.pem", "/etc/haproxy/client-ca.pem"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ParseList() names = %q; want %q", names, want)
+	}
+	if want := []string{"/etc/haproxy/client-ca.pem"}; !reflect.DeepEqual(transactions, want) {
+		t.Errorf("ParseList() transactions = %q; want %q", transactions, want)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	ca := ParseFile(KindCA, "/etc/haproxy/ca.pem", "Filename: /etc/haproxy/ca.pem\n"+
+		"Status: Used\n\n"+
+		"Certificate #1:\n"+
+		"Serial: 11A4D2200DC84376E7D233CAFF39DF44BF8D1211\n"+
+		"notBefore: Apr  1 07:40:53 2021 GMT\n"+
+		"notAfter: Aug 17 07:40:53 2048 GMT\n"+
+		"Algorithm: RSA4096\n"+
+		"SHA1 FingerPrint: A111EF0FEFCDE11D47FE3F33ADCA8435EBEA4864\n"+
+		"Subject: /C=FR/O=HAProxy Technologies/CN=Root CA\n"+
+		"Issuer: /C=FR/O=HAProxy Technologies/CN=Root CA\n\n"+
+		"Certificate #2:\n"+
+		"notAfter: Jan  1 00:00:00 2030 GMT\n"+
+		"SHA1 FingerPrint: B222EF0FEFCDE11D47FE3F33ADCA8435EBEA4864\n"+
+		"Subject: /CN=Intermediate CA\n"+
+		"Issuer: /C=FR/O=HAProxy Technologies/CN=Root CA\n")
+
+	if ca.Status != "Used" || len(ca.Entries) != 2 {
+		t.Fatalf("ParseFile() = %+v; want 2 entries, status Used", ca)
+	}
+	if e := ca.Entries[1]; e.Subject != "/CN=Intermediate CA" || e.Fingerprint != "B222EF0FEFCDE11D47FE3F33ADCA8435EBEA4864" {
+		t.Errorf("second entry = %+v", e)
+	}
+	if want := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC); !ca.Deadline().Equal(want) {
+		t.Errorf("Deadline() = %v; want %v", ca.Deadline(), want)
+	}
+
+	crl := ParseFile(KindCRL, "/etc/haproxy/crl.pem", "Filename: /etc/haproxy/crl.pem\n"+
+		"Status: Used\n\n"+
+		"Certificate Revocation List #1:\n"+
+		"Version 1\n"+
+		"Signature Algorithm: sha256WithRSAEncryption\n"+
+		"Issuer: /C=FR/O=HAProxy Technologies/CN=Intermediate CA2\n"+
+		"Last Update: Apr 23 14:45:39 2021 GMT\n"+
+		"Next Update: Sep  8 14:45:39 2026 GMT\n"+
+		"Revoked Certificates:\n"+
+		"    Serial Number: 1008\n"+
+		"        Revocation Date: Apr 23 14:45:36 2021 GMT\n"+
+		"    Serial Number: 1009\n"+
+		"        Revocation Date: Apr 23 14:45:36 2021 GMT\n")
+
+	if len(crl.Entries) != 1 {
+		t.Fatalf("ParseFile() = %+v; want 1 entry", crl)
+	}
+	e := crl.Entries[0]
+	if e.Revoked != 2 || e.Issuer != "/C=FR/O=HAProxy Technologies/CN=Intermediate CA2" {
+		t.Errorf("CRL entry = %+v", e)
+	}
+
+	before := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, time.September, 10, 0, 0, 0, 0, time.UTC)
+	if crl.Stale(before) {
+		t.Errorf("Stale() before the next update = true; want false")
+	}
+	if !crl.Stale(after) {
+		t.Errorf("Stale() after the next update = false; want true")
+	}
+}
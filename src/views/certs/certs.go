@@ -1,33 +1,103 @@
 package certs
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
 )
 
 type Model interface {
-	CertsView() string
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
 	GetViewport() viewport.Model
-	ViewportFilterMode() bool
-	ViewportFilterInput() string
+	CertsSort() string
+	ExpiringCerts() (count, warnDays int)
+	CertReplacement() (Replacement, bool)
+	CertTransactions() []string
+	DriftedCerts() int
+	OCSPMode() bool
+	StaleOCSP() int
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	viewport.SetContent(m.CertsView())
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	replacement, replacing := m.CertReplacement()
+	if replacing {
+		viewport := m.GetViewport()
+		viewport.SetContent(RenderReplacement(replacement, time.Now()))
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	} else {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	}
 	sb.WriteString("\n")
 
-	if m.ViewportFilterMode() {
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+		return
+	}
+	if m.TextInputMode() {
+		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: preview  esc: cancel)"))
+		return
+	}
+	if m.FilterMode() {
 		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
-	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		return
+	}
+
+	if replacing {
+		if replacement.Stage != "" {
+			sb.WriteString(warnStyle.Render("Transaction in progress: " + replacement.Stage + " " + replacement.Current.Name + "…"))
+		} else {
+			sb.WriteString(hintStyle.Render("j/k: scroll  enter: upload and commit  esc: cancel"))
+		}
+		return
+	}
+
+	if m.OCSPMode() {
+		if stale := m.StaleOCSP(); stale > 0 {
+			sb.WriteString(warnStyle.Render(fmt.Sprintf("%d stale OCSP responses", stale)))
+			sb.WriteString("  ")
+		}
+		if sort := m.CertsSort(); sort != "" {
+			sb.WriteString(hintStyle.Render("Sorted by " + sort + "  "))
+		}
+		sb.WriteString(hintStyle.Render("u: update response  o: certificates  s: sort  /: filter  r: reload"))
+		return
+	}
+
+	if transactions := m.CertTransactions(); len(transactions) > 0 {
+		sb.WriteString(warnStyle.Render("Uncommitted transaction: " + strings.Join(transactions, ", ")))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("C: commit  A: abort  "))
+	}
+	if drifted := m.DriftedCerts(); drifted > 0 {
+		sb.WriteString(warnStyle.Render(fmt.Sprintf("%d changed on disk", drifted)))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("D: hot-load from disk  "))
+	}
+	if count, warnDays := m.ExpiringCerts(); count > 0 {
+		sb.WriteString(warnStyle.Render(fmt.Sprintf("%d expiring within %d days", count, warnDays)))
+		sb.WriteString("  ")
+	}
+	if sort := m.CertsSort(); sort != "" {
+		sb.WriteString(hintStyle.Render("Sorted by " + sort + "  "))
 	}
+	sb.WriteString(hintStyle.Render("U: replace  o: OCSP  s: sort  /: filter  r: reload  ?: help"))
 }
@@ -0,0 +1,138 @@
+package certs
+
+import (
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+// OCSPResponse is a stapled OCSP response as reported by "show ssl
+// ocsp-response".
+type OCSPResponse struct {
+	ID         string // certificate ID key
+	Path       string // certificate file, reported by HAProxy 2.8 and later
+	Serial     string
+	Status     string // good, revoked or unknown
+	Responder  string
+	ProducedAt time.Time
+	ThisUpdate time.Time
+	NextUpdate time.Time
+}
+
+// InitializeOCSPTable builds the table showing the stapled OCSP responses.
+func InitializeOCSPTable() table.Model {
+	columns := []table.Column{
+		{Title: "Certificate", Width: 36},
+		{Title: "Serial", Width: 20},
+		{Title: "Status", Width: 8},
+		{Title: "This Update", Width: 17},
+		{Title: "Next Update", Width: 24},
+		{Title: "Responder", Width: 40},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParseOCSPList parses "show ssl ocsp-response" output into the responses'
+// certificate ID keys, with their certificate path and serial:
+//
+//	# Certificate IDs
+//	  Certificate ID key : 303b300906052b0e03021a050004148a83e0...
+//	  Certificate path : /etc/haproxy/example.pem
+//	    Certificate ID:
+//	      Serial Number: 100A
+func ParseOCSPList(output string) []OCSPResponse {
+	var responses []OCSPResponse
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Certificate ID key":
+			responses = append(responses, OCSPResponse{ID: value})
+		case "Certificate path":
+			if len(responses) > 0 {
+				responses[len(responses)-1].Path = value
+			}
+		case "Serial Number":
+			if len(responses) > 0 {
+				responses[len(responses)-1].Serial = value
+			}
+		}
+	}
+	return responses
+}
+
+// ParseOCSPResponse completes r with "show ssl ocsp-response <id>" output.
+func ParseOCSPResponse(r OCSPResponse, output string) OCSPResponse {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Responder Id":
+			r.Responder = value
+		case "Produced At":
+			r.ProducedAt = ParseTime(value)
+		case "Cert Status":
+			r.Status = value
+		case "This Update":
+			r.ThisUpdate = ParseTime(value)
+		case "Next Update":
+			r.NextUpdate = ParseTime(value)
+		case "Serial Number":
+			if r.Serial == "" {
+				r.Serial = value
+			}
+		}
+	}
+	return r
+}
+
+// Stale reports whether the response is past its next update, so clients
+// receive an outdated staple.
+func (r OCSPResponse) Stale(now time.Time) bool {
+	return !r.NextUpdate.IsZero() && r.NextUpdate.Before(now)
+}
+
+// OCSPRows converts OCSP responses to table rows, flagging stale responses
+// and certificates not reported as good in red.
+func OCSPRows(responses []OCSPResponse, now time.Time) []table.Row {
+	alert := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	rows := make([]table.Row, 0, len(responses))
+	for _, r := range responses {
+		name := r.Path
+		if name == "" {
+			name = r.ID
+		}
+		status := r.Status
+		if status != "" && status != "good" {
+			status = alert.Render(status)
+		}
+		next := r.NextUpdate.Format("2006-01-02 15:04")
+		if r.NextUpdate.IsZero() {
+			next = ""
+		} else if r.Stale(now) {
+			next = alert.Render(next + " (stale)")
+		}
+		this := ""
+		if !r.ThisUpdate.IsZero() {
+			this = r.ThisUpdate.Format("2006-01-02 15:04")
+		}
+		rows = append(rows, table.Row{name, r.Serial, status, this, next, r.Responder})
+	}
+	return rows
+}
@@ -0,0 +1,58 @@
+package certs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOCSP(t *testing.T) {
+	list := "# Certificate IDs\n" +
+		"  Certificate ID key : 303b300906052b0e03021a050004148a83e0060faff709ca7e9b95522a2e81635fda0a0414f652b0e435d5ea923851508f0adbe92d85de007a0202100a\n" +
+		"  Certificate path : /etc/haproxy/example.pem\n" +
+		"    Certificate ID:\n" +
+		"      Issuer Name Hash: 8A83E0060FAFF709CA7E9B95522A2E81635FDA0A\n" +
+		"      Issuer Key Hash: F652B0E435D5EA923851508F0ADBE92D85DE007A\n" +
+		"      Serial Number: 100A\n" +
+		"  Certificate ID key : 303b300906052b0e03021a050004148a83e0060faff709ca7e9b95522a2e81635fda0a0414f652b0e435d5ea923851508f0adbe92d85de007a0202100b\n" +
+		"    Certificate ID:\n" +
+		"      Serial Number: 100B\n"
+
+	responses := ParseOCSPList(list)
+	if len(responses) != 2 {
+		t.Fatalf("ParseOCSPList() returned %d responses; want 2", len(responses))
+	}
+	if r := responses[0]; r.Path != "/etc/haproxy/example.pem" || r.Serial != "100A" {
+		t.Errorf("first response = %+v", r)
+	}
+	if r := responses[1]; r.Path != "" || r.Serial != "100B" {
+		t.Errorf("second response = %+v", r)
+	}
+
+	detail := "OCSP Response Data:\n" +
+		"    OCSP Response Status: successful (0x0)\n" +
+		"    Response Type: Basic OCSP Response\n" +
+		"    Version: 1 (0x0)\n" +
+		"    Responder Id: C = FR, O = HAProxy Technologies, CN = ocsp.haproxy.com\n" +
+		"    Produced At: May 27 15:43:38 2021 GMT\n" +
+		"    Responses:\n" +
+		"    Certificate ID:\n" +
+		"      Hash Algorithm: sha1\n" +
+		"      Serial Number: 100A\n" +
+		"    Cert Status: good\n" +
+		"    This Update: May 27 15:43:38 2021 GMT\n" +
+		"    Next Update: Oct 12 15:43:38 2026 GMT\n"
+
+	r := ParseOCSPResponse(responses[0], detail)
+	if r.Status != "good" || r.Responder != "C = FR, O = HAProxy Technologies, CN = ocsp.haproxy.com" {
+		t.Errorf("ParseOCSPResponse() = %+v", r)
+	}
+	if want := time.Date(2026, time.October, 12, 15, 43, 38, 0, time.UTC); !r.NextUpdate.Equal(want) {
+		t.Errorf("NextUpdate = %v; want %v", r.NextUpdate, want)
+	}
+	if r.Stale(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Stale() before the next update = true; want false")
+	}
+	if !r.Stale(time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Stale() after the next update = false; want true")
+	}
+}
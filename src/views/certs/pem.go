@@ -0,0 +1,98 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParsePEM reads a PEM bundle the way "show ssl cert" would report it once
+// loaded: the first certificate is described, the following ones form the
+// chain. hasKey reports whether the bundle carries a private key.
+func ParsePEM(name string, data []byte) (c Cert, hasKey bool, err error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return Cert{}, false, fmt.Errorf("invalid certificate: %w", err)
+			}
+			chain = append(chain, cert)
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			hasKey = true
+		}
+	}
+	if len(chain) == 0 {
+		return Cert{}, false, errors.New("no certificate found")
+	}
+
+	leaf := chain[0]
+	sum := sha1.Sum(leaf.Raw)
+	c = Cert{
+		Name:        name,
+		Serial:      fmt.Sprintf("%X", leaf.SerialNumber),
+		NotBefore:   leaf.NotBefore,
+		NotAfter:    leaf.NotAfter,
+		Algorithm:   keyAlgorithm(leaf),
+		Fingerprint: fmt.Sprintf("%X", sum[:]),
+		Subject:     OneLineName(leaf.Subject),
+		Issuer:      OneLineName(leaf.Issuer),
+	}
+	for _, dns := range leaf.DNSNames {
+		c.SAN = append(c.SAN, "DNS:"+dns)
+	}
+	for _, ip := range leaf.IPAddresses {
+		c.SAN = append(c.SAN, "IP Address:"+ip.String())
+	}
+	for _, cert := range chain[1:] {
+		c.Chain = append(c.Chain, OneLineName(cert.Subject))
+	}
+	return c, hasKey, nil
+}
+
+// keyAlgorithm names the public key as HAProxy does, e.g. "RSA2048" or
+// "EC256".
+func keyAlgorithm(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("EC%d", key.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return "ED25519"
+	}
+	return cert.PublicKeyAlgorithm.String()
+}
+
+// OneLineName formats a name like OpenSSL's one-line form, e.g.
+// "/C=US/O=Let's Encrypt/CN=R3".
+func OneLineName(name pkix.Name) string {
+	var sb strings.Builder
+	add := func(key string, values ...string) {
+		for _, v := range values {
+			sb.WriteString("/" + key + "=" + v)
+		}
+	}
+	add("C", name.Country...)
+	add("ST", name.Province...)
+	add("L", name.Locality...)
+	add("O", name.Organization...)
+	add("OU", name.OrganizationalUnit...)
+	if name.CommonName != "" {
+		add("CN", name.CommonName)
+	}
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testPEM returns a self-signed certificate for names, with its key when
+// withKey is set.
+func testPEM(t *testing.T, notAfter time.Time, withKey bool, names ...string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0x1F2E),
+		Subject:      pkix.Name{Organization: []string{"Example"}, CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    notAfter.AddDate(0, -3, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if withKey {
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+	}
+	return data
+}
+
+func TestParsePEM(t *testing.T) {
+	notAfter := time.Date(2027, time.March, 1, 12, 0, 0, 0, time.UTC)
+	c, hasKey, err := ParsePEM("new.pem", testPEM(t, notAfter, true, "example.com", "www.example.com"))
+	if err != nil {
+		t.Fatalf("ParsePEM() error: %v", err)
+	}
+	if !hasKey {
+		t.Errorf("ParsePEM() hasKey = false; want true")
+	}
+	if c.Subject != "/O=Example/CN=example.com" {
+		t.Errorf("Subject = %q; want %q", c.Subject, "/O=Example/CN=example.com")
+	}
+	if want := []string{"DNS:example.com", "DNS:www.example.com"}; !reflect.DeepEqual(c.SAN, want) {
+		t.Errorf("SAN = %v; want %v", c.SAN, want)
+	}
+	if c.Algorithm != "EC256" || c.Serial != "1F2E" || !c.NotAfter.Equal(notAfter) {
+		t.Errorf("ParsePEM() = %+v", c)
+	}
+	if len(c.Fingerprint) != 40 {
+		t.Errorf("Fingerprint = %q; want 40 hex digits", c.Fingerprint)
+	}
+
+	if _, _, err := ParsePEM("empty.pem", []byte("not a certificate\n")); err == nil {
+		t.Errorf("ParsePEM() without a certificate returned no error")
+	}
+}
+
+func TestReplacementWarnings(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	current := Cert{
+		Name:        "/etc/haproxy/site.pem",
+		Subject:     "/CN=example.com",
+		SAN:         []string{"DNS:example.com", "DNS:www.example.com"},
+		NotAfter:    now.AddDate(0, 0, 10),
+		Fingerprint: "AA",
+	}
+
+	tests := []struct {
+		name     string
+		next     Cert
+		hasKey   bool
+		expected []string
+	}{
+		{
+			name: "renewal",
+			next: Cert{
+				Subject:     "/CN=example.com",
+				SAN:         []string{"DNS:example.com", "DNS:www.example.com", "DNS:api.example.com"},
+				NotBefore:   now.AddDate(0, 0, -1),
+				NotAfter:    now.AddDate(0, 3, 0),
+				Fingerprint: "BB",
+			},
+			hasKey: true,
+		},
+		{
+			name:   "same certificate without key",
+			next:   current,
+			hasKey: false,
+			expected: []string{
+				"No private key in the file: HAProxy rejects a certificate without its key",
+				"Same certificate as the loaded one",
+			},
+		},
+		{
+			name: "expired and narrower",
+			next: Cert{
+				Subject:     "/CN=www.example.com",
+				SAN:         []string{"DNS:www.example.com"},
+				NotAfter:    now.AddDate(0, 0, -2),
+				Fingerprint: "CC",
+			},
+			hasKey: true,
+			expected: []string{
+				"The new certificate has expired",
+				"The common name changes",
+				"Names no longer covered: DNS:example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Replacement{Current: current, Next: tt.next, HasKey: tt.hasKey}
+			if result := r.Warnings(now); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Warnings() = %q; want %q", result, tt.expected)
+			}
+		})
+	}
+}
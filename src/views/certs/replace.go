@@ -0,0 +1,112 @@
+package certs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Replacement is a certificate loaded from a local file to replace a loaded
+// one through a "set ssl cert" transaction.
+type Replacement struct {
+	File    string
+	Current Cert
+	Next    Cert
+	HasKey  bool
+	Stage   string // "" while previewing, then the command in flight
+}
+
+// Warnings lists the problems worth a second look before replacing the
+// certificate.
+func (r Replacement) Warnings(now time.Time) []string {
+	var warnings []string
+	if !r.HasKey {
+		warnings = append(warnings, "No private key in the file: HAProxy rejects a certificate without its key")
+	}
+	if r.Next.Fingerprint == r.Current.Fingerprint {
+		warnings = append(warnings, "Same certificate as the loaded one")
+	}
+	if !r.Next.NotAfter.IsZero() && r.Next.DaysLeft(now) < 0 {
+		warnings = append(warnings, "The new certificate has expired")
+	} else if r.Next.NotBefore.After(now) {
+		warnings = append(warnings, "The new certificate is not valid before "+formatDate(r.Next.NotBefore))
+	}
+	if CommonName(r.Next.Subject) != CommonName(r.Current.Subject) {
+		warnings = append(warnings, "The common name changes")
+	}
+	if missing := missingNames(r.Current.SAN, r.Next.SAN); len(missing) > 0 {
+		warnings = append(warnings, "Names no longer covered: "+strings.Join(missing, ", "))
+	}
+	return warnings
+}
+
+// missingNames returns the names of current absent from next.
+func missingNames(current, next []string) []string {
+	covered := make(map[string]bool, len(next))
+	for _, name := range next {
+		covered[name] = true
+	}
+	var missing []string
+	for _, name := range current {
+		if !covered[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// RenderReplacement compares the loaded certificate with its replacement,
+// highlighting the fields that change.
+func RenderReplacement(r Replacement, now time.Time) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Replace " + r.Current.Name))
+	sb.WriteString("\n")
+	sb.WriteString(labelStyle.Render("with " + r.File))
+	sb.WriteString("\n\n")
+
+	expiry := func(c Cert) string {
+		if c.NotAfter.IsZero() {
+			return ""
+		}
+		return fmt.Sprintf("%s (%d days)", formatDate(c.NotAfter), c.DaysLeft(now))
+	}
+	fields := []struct {
+		label         string
+		current, next string
+	}{
+		{"CN", CommonName(r.Current.Subject), CommonName(r.Next.Subject)},
+		{"SAN", strings.Join(r.Current.SAN, ", "), strings.Join(r.Next.SAN, ", ")},
+		{"Issuer", CommonName(r.Current.Issuer), CommonName(r.Next.Issuer)},
+		{"Not Before", formatDate(r.Current.NotBefore), formatDate(r.Next.NotBefore)},
+		{"Not After", expiry(r.Current), expiry(r.Next)},
+		{"Key", r.Current.Algorithm, r.Next.Algorithm},
+		{"Chain", fmt.Sprint(len(r.Current.Chain)), fmt.Sprint(len(r.Next.Chain))},
+		{"Serial", r.Current.Serial, r.Next.Serial},
+		{"Fingerprint", r.Current.Fingerprint, r.Next.Fingerprint},
+	}
+	for _, f := range fields {
+		sb.WriteString(labelStyle.Render(fmt.Sprintf("%-12s", f.label)))
+		sb.WriteString(" " + f.current + "\n")
+		next := "→ " + f.next
+		if f.next != f.current {
+			next = changedStyle.Render(next)
+		}
+		sb.WriteString(fmt.Sprintf("%-12s %s\n", "", next))
+	}
+
+	if warnings := r.Warnings(now); len(warnings) > 0 {
+		sb.WriteString("\n")
+		for _, w := range warnings {
+			sb.WriteString(warnStyle.Render("⚠ " + w))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
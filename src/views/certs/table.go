@@ -0,0 +1,239 @@
+package certs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// CriticalDays is the number of days to expiry below which a certificate
+// is shown in red.
+const CriticalDays = 7
+
+// Cert holds the details "show ssl cert <name>" reports for a certificate.
+type Cert struct {
+	Name        string
+	Status      string
+	Serial      string
+	NotBefore   time.Time
+	NotAfter    time.Time
+	SAN         []string
+	Algorithm   string
+	Fingerprint string // SHA1
+	Subject     string
+	Issuer      string
+	Chain       []string  // chain certificate subjects
+	Disk        *DiskFile // local PEM file, nil when there is none
+}
+
+// DiskFile is the local PEM file a loaded certificate was read from.
+type DiskFile struct {
+	Path        string
+	Fingerprint string // SHA1 of the file's first certificate
+	Err         string // why the file could not be read or parsed
+}
+
+// Drifted reports whether the certificate on disk differs from the loaded
+// one, as when a renewal was written but not loaded.
+func (c Cert) Drifted() bool {
+	return c.Disk != nil && c.Disk.Err == "" && c.Disk.Fingerprint != c.Fingerprint
+}
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Certificate", Width: 36},
+		{Title: "CN", Width: 24},
+		{Title: "SAN", Width: 30},
+		{Title: "Issuer", Width: 20},
+		{Title: "Not Before", Width: 10},
+		{Title: "Not After", Width: 10},
+		{Title: "Days", Width: 6},
+		{Title: "Disk", Width: 8},
+		{Title: "Key", Width: 10},
+		{Title: "Chain", Width: 6},
+		{Title: "Serial", Width: 34},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParseList parses "show ssl cert" output into certificate names. Names of
+// certificates in an uncommitted transaction, prefixed with "*", are
+// returned separately.
+func ParseList(output string) (names, transactions []string) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "*"):
+			transactions = append(transactions, strings.TrimPrefix(line, "*"))
+		default:
+			names = append(names, line)
+		}
+	}
+	return names, transactions
+}
+
+// ParseCert parses "show ssl cert <name>" output. The first certificate of
+// a bundle is kept; the chain is listed through its subjects.
+func ParseCert(name, output string) Cert {
+	c := Cert{Name: name}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Filename":
+			if c.Name == "" {
+				c.Name = strings.TrimPrefix(value, "*")
+			}
+		case "Status":
+			c.Status = value
+		case "Serial":
+			c.Serial = value
+		case "notBefore":
+			c.NotBefore = ParseTime(value)
+		case "notAfter":
+			c.NotAfter = ParseTime(value)
+		case "Subject Alternative Name":
+			for _, san := range strings.Split(value, ",") {
+				if san = strings.TrimSpace(san); san != "" {
+					c.SAN = append(c.SAN, san)
+				}
+			}
+		case "Algorithm":
+			c.Algorithm = value
+		case "SHA1 FingerPrint":
+			c.Fingerprint = value
+		case "Subject":
+			c.Subject = value
+		case "Issuer":
+			c.Issuer = value
+		case "Chain Subject":
+			c.Chain = append(c.Chain, value)
+		}
+	}
+	return c
+}
+
+// ParseTime parses dates as OpenSSL prints them, e.g.
+// "Sep  9 12:05:57 2021 GMT".
+func ParseTime(value string) time.Time {
+	t, _ := time.Parse("Jan _2 15:04:05 2006 MST", strings.Join(strings.Fields(value), " "))
+	return t
+}
+
+// CommonName returns the CN of an OpenSSL one-line name such as
+// "/C=US/O=Let's Encrypt/CN=R3", or the name itself when it has none.
+func CommonName(name string) string {
+	for _, part := range strings.Split(name, "/") {
+		if cn, ok := strings.CutPrefix(part, "CN="); ok {
+			return cn
+		}
+	}
+	return name
+}
+
+// DaysLeft returns the number of whole days before c expires, negative once
+// it has.
+func (c Cert) DaysLeft(now time.Time) int {
+	d := c.NotAfter.Sub(now)
+	days := int(d / (24 * time.Hour))
+	if d < 0 && d%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+// Expiring reports whether c expires within warnDays.
+func (c Cert) Expiring(now time.Time, warnDays int) bool {
+	return !c.NotAfter.IsZero() && c.DaysLeft(now) < warnDays
+}
+
+// Rows converts certificates to table rows, coloring the days to expiry
+// green, yellow within warnDays and red within CriticalDays or once
+// expired.
+func Rows(certs []Cert, now time.Time, warnDays int) []table.Row {
+	rows := make([]table.Row, 0, len(certs))
+	for _, c := range certs {
+		days := ""
+		if !c.NotAfter.IsZero() {
+			days = colorizeDays(c.DaysLeft(now), warnDays)
+		}
+		rows = append(rows, table.Row{
+			c.Name,
+			CommonName(c.Subject),
+			strings.Join(c.SAN, ", "),
+			CommonName(c.Issuer),
+			formatDate(c.NotBefore),
+			formatDate(c.NotAfter),
+			days,
+			diskState(c),
+			c.Algorithm,
+			fmt.Sprint(len(c.Chain)),
+			c.Serial,
+		})
+	}
+	return rows
+}
+
+// diskState describes how the file on disk compares to the loaded
+// certificate: "same", "changed" in red, "error", or "" without a file.
+func diskState(c Cert) string {
+	switch {
+	case c.Disk == nil:
+		return ""
+	case c.Disk.Err != "":
+		return "error"
+	case c.Drifted():
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render("changed")
+	}
+	return "same"
+}
+
+func colorizeDays(days, warnDays int) string {
+	color := "2"
+	switch {
+	case days < CriticalDays:
+		color = "1"
+	case days < warnDays:
+		color = "3"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(fmt.Sprint(days))
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,94 @@
+package certs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	input := "# transaction\n*/etc/haproxy/certs/site.pem\n# filename\n/etc/haproxy/certs/site.pem\n/etc/haproxy/certs/api.pem\n"
+
+	names, transactions := ParseList(input)
+	if !reflect.DeepEqual(names, []string{"/etc/haproxy/certs/site.pem", "/etc/haproxy/certs/api.pem"}) {
+		t.Errorf("ParseList() names = %q", names)
+	}
+	if !reflect.DeepEqual(transactions, []string{"/etc/haproxy/certs/site.pem"}) {
+		t.Errorf("ParseList() transactions = %q", transactions)
+	}
+}
+
+func TestParseCert(t *testing.T) {
+	input := `Filename: /etc/haproxy/certs/site.pem
+Status: Used
+Serial: 0D933C1B1089BF660AE5253A245BB388
+notBefore: Sep  9 12:05:57 2024 GMT
+notAfter: Dec  8 12:05:56 2024 GMT
+Subject Alternative Name: DNS:example.com, DNS:www.example.com
+Algorithm: EC256
+SHA1 FingerPrint: C2C0D2A5E94D9F7B7B8F0B9C0E7E6E5A4D3C2B1A
+Subject: /CN=example.com
+Issuer: /C=US/O=Let's Encrypt/CN=E5
+Chain Subject: /C=US/O=Let's Encrypt/CN=E5
+Chain Issuer: /C=US/O=Internet Security Research Group/CN=ISRG Root X1
+`
+	c := ParseCert("/etc/haproxy/certs/site.pem", input)
+
+	expected := Cert{
+		Name:        "/etc/haproxy/certs/site.pem",
+		Status:      "Used",
+		Serial:      "0D933C1B1089BF660AE5253A245BB388",
+		NotBefore:   time.Date(2024, time.September, 9, 12, 5, 57, 0, time.UTC),
+		NotAfter:    time.Date(2024, time.December, 8, 12, 5, 56, 0, time.UTC),
+		SAN:         []string{"DNS:example.com", "DNS:www.example.com"},
+		Algorithm:   "EC256",
+		Fingerprint: "C2C0D2A5E94D9F7B7B8F0B9C0E7E6E5A4D3C2B1A",
+		Subject:     "/CN=example.com",
+		Issuer:      "/C=US/O=Let's Encrypt/CN=E5",
+		Chain:       []string{"/C=US/O=Let's Encrypt/CN=E5"},
+	}
+	if !c.NotBefore.Equal(expected.NotBefore) || !c.NotAfter.Equal(expected.NotAfter) {
+		t.Errorf("ParseCert() dates = %v, %v; want %v, %v", c.NotBefore, c.NotAfter, expected.NotBefore, expected.NotAfter)
+	}
+	c.NotBefore, c.NotAfter = expected.NotBefore, expected.NotAfter
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("ParseCert() = %+v; want %+v", c, expected)
+	}
+}
+
+func TestDaysLeft(t *testing.T) {
+	now := time.Date(2024, time.December, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		expected int
+		expiring bool
+	}{
+		{name: "far", notAfter: now.Add(90 * 24 * time.Hour), expected: 90, expiring: false},
+		{name: "within warning", notAfter: now.Add(10*24*time.Hour + time.Hour), expected: 10, expiring: true},
+		{name: "expires in hours", notAfter: now.Add(5 * time.Hour), expected: 0, expiring: true},
+		{name: "expired", notAfter: now.Add(-5 * time.Hour), expected: -1, expiring: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Cert{NotAfter: tt.notAfter}
+			if days := c.DaysLeft(now); days != tt.expected {
+				t.Errorf("DaysLeft() = %d; want %d", days, tt.expected)
+			}
+			if expiring := c.Expiring(now, 30); expiring != tt.expiring {
+				t.Errorf("Expiring() = %v; want %v", expiring, tt.expiring)
+			}
+		})
+	}
+}
+
+func TestCommonName(t *testing.T) {
+	if cn := CommonName("/C=US/O=Let's Encrypt/CN=R3"); cn != "R3" {
+		t.Errorf("CommonName() = %q; want %q", cn, "R3")
+	}
+	if cn := CommonName("/O=No CN"); cn != "/O=No CN" {
+		t.Errorf("CommonName() without CN = %q", cn)
+	}
+}
@@ -0,0 +1,45 @@
+package crtlists
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	OpenCrtList() string
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	sb.WriteString(baseStyle.Render(m.TableView()))
+	sb.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+	} else if m.TextInputMode() {
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: next  esc: cancel)"))
+	} else if m.FilterMode() {
+		sb.WriteString(inputStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+	} else if open := m.OpenCrtList(); open != "" {
+		sb.WriteString(inputStyle.Render(open))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("a: add entry  x: delete entry  n: new cert  esc: back  /: filter  r: reload"))
+	} else {
+		sb.WriteString(hintStyle.Render("enter: open crt-list  n: new cert  /: filter  r: reload  ?: help"))
+	}
+}
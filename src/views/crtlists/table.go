@@ -0,0 +1,110 @@
+package crtlists
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// InitializeListTable builds the table listing crt-list files.
+func InitializeListTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Crt-list", Width: 60},
+	})
+}
+
+// InitializeEntriesTable builds the table showing the entries of a
+// crt-list: the certificate, its line in the file, its SSL options and SNI
+// filters.
+func InitializeEntriesTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Certificate", Width: 40},
+		{Title: "Line", Width: 5},
+		{Title: "Options", Width: 36},
+		{Title: "SNI Filters", Width: 40},
+	})
+}
+
+// ParseList parses "show ssl crt-list" output, one file per line.
+func ParseList(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, table.Row{line})
+	}
+	return rows
+}
+
+// ParseEntries parses "show ssl crt-list -n <file>" output into
+// Certificate, Line, Options and SNI Filters rows. A line looks like:
+//
+//	/etc/haproxy/example.pem:2 [alpn h2,http/1.1 ocsp-update on] example.com !www.example.com
+func ParseEntries(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cert, rest, _ := strings.Cut(line, " ")
+		number := ""
+		if i := strings.LastIndex(cert, ":"); i > 0 && isNumber(cert[i+1:]) {
+			cert, number = cert[:i], cert[i+1:]
+		}
+
+		options := ""
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, "[") {
+			if end := strings.Index(rest, "]"); end > 0 {
+				options = strings.TrimSpace(rest[1:end])
+				rest = strings.TrimSpace(rest[end+1:])
+			}
+		}
+		rows = append(rows, table.Row{cert, number, options, strings.Join(strings.Fields(rest), " ")})
+	}
+	return rows
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,37 @@
+package crtlists
+
+import (
+	"reflect"
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseList(t *testing.T) {
+	input := "/etc/haproxy/crt-list.txt\n/etc/haproxy/internal.lst\n\n"
+	expected := []table.Row{{"/etc/haproxy/crt-list.txt"}, {"/etc/haproxy/internal.lst"}}
+
+	result := ParseList(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseList() = %q; want %q", result, expected)
+	}
+}
+
+func TestParseEntries(t *testing.T) {
+	input := "# /etc/haproxy/crt-list.txt\n" +
+		"/etc/haproxy/example.pem:1 [alpn h2,http/1.1 ocsp-update on] example.com  !www.example.com\n" +
+		"/etc/haproxy/default.pem:2\n" +
+		"/etc/haproxy/wildcard.pem:3 *.example.org\n" +
+		"/etc/haproxy/plain.pem [verify none]\n"
+	expected := []table.Row{
+		{"/etc/haproxy/example.pem", "1", "alpn h2,http/1.1 ocsp-update on", "example.com !www.example.com"},
+		{"/etc/haproxy/default.pem", "2", "", ""},
+		{"/etc/haproxy/wildcard.pem", "3", "", "*.example.org"},
+		{"/etc/haproxy/plain.pem", "", "verify none", ""},
+	}
+
+	result := ParseEntries(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseEntries() = %q; want %q", result, expected)
+	}
+}
@@ -0,0 +1,169 @@
+package error
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Capture is one request or response HAProxy rejected, as reported by
+// "show errors".
+type Capture struct {
+	Time     string
+	Kind     string // "request" or "response"
+	Frontend string
+	Backend  string
+	Server   string
+	Source   string
+	Event    string
+	Position int // offset of the failing byte, -1 if unknown
+	Error    string
+	Info     []string // capture details as printed by HAProxy
+	Buffer   []byte
+}
+
+// ParseCaptures parses "show errors" output. Each capture starts with a
+// line such as
+//
+//	[10/Jul/2023:14:21:59.071] frontend fe_http (#2): invalid request
+//
+// followed by indented details and the buffer dump, one line per chunk:
+//
+//	00000  GET /\x01 HTTP/1.1\r\n
+func ParseCaptures(output string) []Capture {
+	var captures []Capture
+	var c *Capture
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			captures = append(captures, parseCaptureHeader(line))
+			c = &captures[len(captures)-1]
+			continue
+		}
+		if c == nil || trimmed == "" {
+			continue
+		}
+		if offset, data, ok := parseDumpLine(strings.TrimLeft(line, " ")); ok {
+			if offset <= len(c.Buffer) {
+				c.Buffer = append(c.Buffer[:offset], data...)
+			}
+			continue
+		}
+		c.Info = append(c.Info, trimmed)
+		parseCaptureInfo(c, trimmed)
+	}
+	return captures
+}
+
+// parseCaptureHeader parses the first line of a capture.
+func parseCaptureHeader(line string) Capture {
+	c := Capture{Position: -1}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		c.Error = line
+		return c
+	}
+	c.Time = line[1:end]
+	rest := strings.TrimSpace(line[end+1:])
+
+	// "frontend fe_http (#2): invalid request"
+	where, what, _ := strings.Cut(rest, ": ")
+	c.Error = strings.TrimSpace(what)
+	fields := strings.Fields(where)
+	if len(fields) >= 2 {
+		switch fields[0] {
+		case "frontend":
+			c.Kind = "request"
+			c.Frontend = fields[1]
+		case "backend":
+			c.Kind = "response"
+			c.Backend = fields[1]
+		}
+	}
+	return c
+}
+
+// parseCaptureInfo picks the peer proxy, server, event, source and error
+// position from a detail line such as
+//
+//	backend <NONE> (#-1), server <NONE> (#-1), event #1, src 127.0.0.1:53142
+func parseCaptureInfo(c *Capture, line string) {
+	for _, part := range strings.Split(line, ",") {
+		fields := strings.Fields(part)
+		if len(fields) < 2 {
+			continue
+		}
+		switch {
+		case fields[0] == "frontend" && c.Frontend == "":
+			c.Frontend = fields[1]
+		case fields[0] == "backend" && c.Backend == "":
+			c.Backend = fields[1]
+		case fields[0] == "server":
+			c.Server = fields[1]
+		case fields[0] == "event":
+			c.Event = strings.TrimPrefix(fields[1], "#")
+		case fields[0] == "src":
+			c.Source = fields[1]
+		case fields[0] == "error" && len(fields) >= 4 && fields[1] == "at" && fields[2] == "position":
+			if n, err := strconv.Atoi(fields[3]); err == nil {
+				c.Position = n
+			}
+		}
+	}
+}
+
+// parseDumpLine parses a buffer dump line: a decimal offset, followed by
+// "+" when it continues the previous line or a space otherwise, a space and
+// the escaped data.
+func parseDumpLine(line string) (offset int, data []byte, ok bool) {
+	i := 0
+	for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+		i++
+	}
+	if i < 5 {
+		return 0, nil, false
+	}
+	offset, err := strconv.Atoi(line[:i])
+	if err != nil {
+		return 0, nil, false
+	}
+	rest := line[i:]
+	if len(rest) < 2 || (rest[0] != ' ' && rest[0] != '+') || rest[1] != ' ' {
+		return 0, nil, false
+	}
+	return offset, unescape(rest[2:]), true
+}
+
+// unescape reverts HAProxy's dump escaping: \r, \n, \t, \e, \\ and \xHH.
+func unescape(s string) []byte {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			out = append(out, s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'r':
+			out = append(out, '\r')
+		case 'n':
+			out = append(out, '\n')
+		case 't':
+			out = append(out, '\t')
+		case 'e':
+			out = append(out, 0x1b)
+		case 'x':
+			if i+2 < len(s) {
+				if b, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					out = append(out, byte(b))
+					i += 2
+					continue
+				}
+			}
+			out = append(out, '\\', 'x')
+		default:
+			out = append(out, s[i])
+		}
+	}
+	return out
+}
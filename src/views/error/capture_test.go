@@ -0,0 +1,81 @@
+package error
+
+import (
+	"reflect"
+	"testing"
+)
+
+const sampleErrors = `Total events captured on [10/Jul/2023:14:22:11.123] : 2
+
+[10/Jul/2023:14:21:59.071] frontend fe_http (#2): invalid request
+  backend <NONE> (#-1), server <NONE> (#-1), event #1, src 127.0.0.1:53142
+  buffer starts at 0 (including 0 out), 16338 free,
+  len 36, wraps at 16336, error at position 5
+  H1 connection flags 0x00000000, H1 stream flags 0x00000810
+  H1 msg state MSG_RQURI(4), H1 msg flags 0x00001400
+  H1 chunk len 0 bytes, H1 body len 0 bytes :
+
+  00000  GET /\x01 HTTP/1.1\r\n
+  00017  Host: localhost\r\n
+  00034  \r\n
+
+[10/Jul/2023:14:22:05.402] backend be_app (#3): invalid response
+  frontend fe_http (#2), server app1 (#1), event #2, src 10.0.0.9:41000
+  buffer starts at 0 (including 0 out), 16360 free,
+  len 24, wraps at 16336, error at position 9
+  H1 connection flags 0x00000000, H1 stream flags 0x00000810
+  H1 msg state MSG_RPCODE(15), H1 msg flags 0x00001400
+  H1 chunk len 0 bytes, H1 body len 0 bytes :
+
+  00000  HTTP/1.1 2OO OK\r\n
+  00017  Server: \\x
+  00027+ \r\n
+`
+
+func TestParseCaptures(t *testing.T) {
+	captures := ParseCaptures(sampleErrors)
+	if len(captures) != 2 {
+		t.Fatalf("ParseCaptures() returned %d captures; want 2", len(captures))
+	}
+
+	req := captures[0]
+	if req.Time != "10/Jul/2023:14:21:59.071" || req.Kind != "request" || req.Error != "invalid request" {
+		t.Errorf("request header = %q %q %q", req.Time, req.Kind, req.Error)
+	}
+	if req.Frontend != "fe_http" || req.Backend != "<NONE>" || req.Event != "1" || req.Source != "127.0.0.1:53142" || req.Position != 5 {
+		t.Errorf("request details = %+v", req)
+	}
+	expectedBuffer := []byte("GET /\x01 HTTP/1.1\r\nHost: localhost\r\n\r\n")
+	if !reflect.DeepEqual(req.Buffer, expectedBuffer) {
+		t.Errorf("request buffer = %q; want %q", req.Buffer, expectedBuffer)
+	}
+	if req.Buffer[req.Position] != 0x01 {
+		t.Errorf("byte at error position = %q; want \\x01", req.Buffer[req.Position])
+	}
+	if len(req.Info) != 6 {
+		t.Errorf("request info has %d lines; want 6", len(req.Info))
+	}
+
+	resp := captures[1]
+	if resp.Kind != "response" || resp.Backend != "be_app" || resp.Frontend != "fe_http" || resp.Server != "app1" || resp.Position != 9 {
+		t.Errorf("response details = %+v", resp)
+	}
+	expectedBuffer = []byte("HTTP/1.1 2OO OK\r\nServer: \\x\r\n")
+	if !reflect.DeepEqual(resp.Buffer, expectedBuffer) {
+		t.Errorf("response buffer = %q; want %q", resp.Buffer, expectedBuffer)
+	}
+}
+
+func TestParseCapturesEmpty(t *testing.T) {
+	if captures := ParseCaptures("Total events captured on [10/Jul/2023:14:22:11.123] : 0\n"); len(captures) != 0 {
+		t.Errorf("ParseCaptures() = %+v; want no captures", captures)
+	}
+}
+
+func TestRows(t *testing.T) {
+	rows := Rows(ParseCaptures(sampleErrors))
+	expected := []string{"10/Jul/2023:14:21:59.071", "1", "request", "fe_http", "<NONE>", "<NONE>", "127.0.0.1:53142", "5", "invalid request"}
+	if !reflect.DeepEqual([]string(rows[0]), expected) {
+		t.Errorf("Rows()[0] = %q; want %q", rows[0], expected)
+	}
+}
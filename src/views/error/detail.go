@@ -0,0 +1,114 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+const hexBytesPerLine = 16
+
+var (
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	infoStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	escStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	errStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("1")).Bold(true)
+)
+
+// RenderDetail renders a capture with its buffer decoded as HTTP text, or
+// as a hex dump, with the failing byte highlighted.
+func RenderDetail(c Capture, hex bool) string {
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("[%s] %s: %s", c.Time, c.Kind, c.Error)) + "\n")
+	for _, line := range c.Info {
+		sb.WriteString(infoStyle.Render("  "+line) + "\n")
+	}
+	sb.WriteString("\n")
+
+	if hex {
+		sb.WriteString(renderHex(c.Buffer, c.Position))
+	} else {
+		sb.WriteString(renderText(c.Buffer, c.Position))
+	}
+	return sb.String()
+}
+
+// renderText shows the buffer as the HTTP message it holds: line breaks
+// are kept, other control bytes are escaped.
+func renderText(buf []byte, pos int) string {
+	var sb strings.Builder
+	for i, b := range buf {
+		if i == pos {
+			// Control characters get a visible stand-in to be highlighted
+			sb.WriteString(errStyle.Render(visibleByte(b)))
+			if b == '\n' {
+				sb.WriteString("\n")
+			}
+			continue
+		}
+		switch {
+		case b == '\n', b == '\t':
+			sb.WriteByte(b)
+		case b == '\r':
+			// Dropped, the line break follows
+		case b < 0x20 || b >= 0x7f:
+			sb.WriteString(escStyle.Render(visibleByte(b)))
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	if pos >= len(buf) {
+		sb.WriteString(errStyle.Render("<end>"))
+	}
+	return sb.String()
+}
+
+// visibleByte renders b as itself when printable, escaped otherwise.
+func visibleByte(b byte) string {
+	switch {
+	case b == '\r':
+		return `\r`
+	case b == '\n':
+		return `\n`
+	case b == '\t':
+		return `\t`
+	case b < 0x20 || b >= 0x7f:
+		return fmt.Sprintf(`\x%02x`, b)
+	}
+	return string(rune(b))
+}
+
+// renderHex shows the buffer as a classic hex dump.
+func renderHex(buf []byte, pos int) string {
+	var sb strings.Builder
+	for start := 0; start < len(buf); start += hexBytesPerLine {
+		end := min(start+hexBytesPerLine, len(buf))
+		sb.WriteString(infoStyle.Render(fmt.Sprintf("%05d  ", start)))
+
+		for i := start; i < start+hexBytesPerLine; i++ {
+			switch {
+			case i >= end:
+				sb.WriteString("   ")
+			case i == pos:
+				sb.WriteString(errStyle.Render(fmt.Sprintf("%02x", buf[i])) + " ")
+			default:
+				sb.WriteString(fmt.Sprintf("%02x ", buf[i]))
+			}
+		}
+
+		sb.WriteString(" |")
+		for i := start; i < end; i++ {
+			ch := "."
+			if buf[i] >= 0x20 && buf[i] < 0x7f {
+				ch = string(rune(buf[i]))
+			}
+			if i == pos {
+				ch = errStyle.Render(ch)
+			}
+			sb.WriteString(ch)
+		}
+		sb.WriteString("|\n")
+	}
+	return sb.String()
+}
@@ -8,26 +8,43 @@ import (
 )
 
 type Model interface {
-	ErrorView() string
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ErrorDetail() (c Capture, hex bool, ok bool)
+	ErrorsSummary() string
 	GetViewport() viewport.Model
-	ViewportFilterMode() bool
-	ViewportFilterInput() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	viewport.SetContent(m.ErrorView())
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	c, hex, ok := m.ErrorDetail()
+	if ok {
+		viewport := m.GetViewport()
+		viewport.SetContent(RenderDetail(c, hex))
+		sb.WriteString(baseStyle.Render(viewport.View()))
+		sb.WriteString("\n")
+		mode := "x: hex view"
+		if hex {
+			mode = "x: text view"
+		}
+		sb.WriteString(hintStyle.Render("j/k: scroll  " + mode + "  esc: back"))
+		return
+	}
+
+	sb.WriteString(baseStyle.Render(m.TableView()))
 	sb.WriteString("\n")
 
-	if m.ViewportFilterMode() {
+	if m.FilterMode() {
 		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
 	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		if summary := m.ErrorsSummary(); summary != "" {
+			sb.WriteString(hintStyle.Render(summary + "  "))
+		}
+		sb.WriteString(hintStyle.Render("enter: decode capture  /: filter  ?: help"))
 	}
 }
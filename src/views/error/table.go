@@ -0,0 +1,63 @@
+package error
+
+import (
+	"strconv"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Time", Width: 26},
+		{Title: "Event", Width: 6},
+		{Title: "Kind", Width: 9},
+		{Title: "Frontend", Width: 16},
+		{Title: "Backend", Width: 16},
+		{Title: "Server", Width: 14},
+		{Title: "Source", Width: 22},
+		{Title: "Pos", Width: 6},
+		{Title: "Error", Width: 24},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// Rows converts captures to table rows, in the order HAProxy lists them.
+func Rows(captures []Capture) []table.Row {
+	rows := make([]table.Row, 0, len(captures))
+	for _, c := range captures {
+		pos := ""
+		if c.Position >= 0 {
+			pos = strconv.Itoa(c.Position)
+		}
+		rows = append(rows, table.Row{
+			c.Time, c.Event, c.Kind, c.Frontend, c.Backend, c.Server, c.Source, pos, c.Error,
+		})
+	}
+	return rows
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,22 @@
+package events
+
+// Buffer keeps the last Max lines of a stream, so following a busy ring
+// uses bounded memory.
+type Buffer struct {
+	Max     int
+	Lines   []string
+	Dropped int // oldest lines discarded to stay within Max
+}
+
+// Append adds lines, discarding the oldest ones beyond Max.
+func (b *Buffer) Append(lines ...string) {
+	b.Lines = append(b.Lines, lines...)
+	over := len(b.Lines) - b.Max
+	if b.Max <= 0 || over <= 0 {
+		return
+	}
+	n := copy(b.Lines, b.Lines[over:])
+	clear(b.Lines[n:])
+	b.Lines = b.Lines[:n]
+	b.Dropped += over
+}
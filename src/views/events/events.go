@@ -1,6 +1,7 @@
 package events
 
 import (
+	"fmt"
 	"strings"
 
 	"charm.land/bubbles/v2/viewport"
@@ -8,26 +9,65 @@ import (
 )
 
 type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
 	EventsView() string
+	// EventStream returns the followed ring, or "" when listing rings, and
+	// the state of the stream.
+	EventStream() (ring string, paused bool, held, dropped int, ended string)
 	GetViewport() viewport.Model
 	ViewportFilterMode() bool
 	ViewportFilterInput() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	viewport.SetContent(m.EventsView())
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	ring, paused, held, dropped, ended := m.EventStream()
+	if ring == "" {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	} else {
+		viewport := m.GetViewport()
+		viewport.SetContent(m.EventsView())
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	}
 	sb.WriteString("\n")
 
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 	if m.ViewportFilterMode() {
-		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
-	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		return
+	}
+	if m.FilterMode() {
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+	if ring == "" {
+		sb.WriteString(hintStyle.Render("enter: follow ring  n: follow new events only  /: filter  ?: help"))
+		return
+	}
+
+	RenderStatus(sb, ring, paused, held, dropped, ended)
+	sb.WriteString("  ")
+	sb.WriteString(hintStyle.Render("p: pause/resume  j/k: scroll  /: filter  esc: back to rings"))
+}
+
+// RenderStatus describes a followed ring: whether it is paused, with the
+// lines received since, or was closed, and how many lines were discarded.
+func RenderStatus(sb *strings.Builder, ring string, paused bool, held, dropped int, ended string) {
+	status := "Following " + ring
+	switch {
+	case ended != "":
+		status = ring + ": " + ended
+	case paused:
+		status = fmt.Sprintf("Paused on %s, %d new line(s)", ring, held)
+	}
+	if dropped > 0 {
+		status += fmt.Sprintf(" (%d oldest line(s) discarded)", dropped)
 	}
+	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(status))
 }
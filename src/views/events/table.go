@@ -0,0 +1,102 @@
+package events
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// Ring is an event sink as listed by "show events".
+type Ring struct {
+	Name        string
+	Type        string // buffer for rings, fd for stdout/stderr sinks
+	Dropped     string
+	Description string
+}
+
+// Tailable reports whether "show events <name> -w" can follow the sink,
+// which only rings support.
+func (r Ring) Tailable() bool {
+	return r.Type == "buffer"
+}
+
+// InitializeTable builds the table listing the event sinks.
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Ring", Width: 20},
+		{Title: "Type", Width: 8},
+		{Title: "Dropped", Width: 12},
+		{Title: "Description", Width: 60},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParseRings parses "show events" output, one sink per line after the
+// header:
+//
+//	buf0       : type=buffer, 0 dropped, Buffer for important messages
+func ParseRings(output string) []Ring {
+	var rings []Ring
+	for _, line := range strings.Split(output, "\n") {
+		name, rest, ok := strings.Cut(line, " : ")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		r := Ring{Name: strings.TrimSpace(name)}
+		fields := strings.SplitN(rest, ", ", 3)
+		for i, f := range fields {
+			f = strings.TrimSpace(f)
+			switch {
+			case strings.HasPrefix(f, "type="):
+				r.Type = strings.TrimPrefix(f, "type=")
+			case strings.HasSuffix(f, " dropped"):
+				r.Dropped = strings.TrimSuffix(f, " dropped")
+			case i == len(fields)-1:
+				r.Description = f
+			}
+		}
+		rings = append(rings, r)
+	}
+	return rings
+}
+
+// Rows converts rings to Ring, Type, Dropped and Description rows, with
+// sinks that dropped events in red.
+func Rows(rings []Ring) []table.Row {
+	alert := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	rows := make([]table.Row, 0, len(rings))
+	for _, r := range rings {
+		dropped := r.Dropped
+		if dropped != "" && dropped != "0" {
+			dropped = alert.Render(dropped)
+		}
+		rows = append(rows, table.Row{r.Name, r.Type, dropped, r.Description})
+	}
+	return rows
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
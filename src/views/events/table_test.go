@@ -0,0 +1,38 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRings(t *testing.T) {
+	input := "Supported events sinks are listed below. Add -w(wait), -n(new). Any key to stop\n" +
+		"    stdout     : type=fd, 0 dropped, standard output (fd#1)\n" +
+		"    buf0       : type=buffer, 12 dropped, Buffer for important messages\n" +
+		"\n"
+	expected := []Ring{
+		{Name: "stdout", Type: "fd", Dropped: "0", Description: "standard output (fd#1)"},
+		{Name: "buf0", Type: "buffer", Dropped: "12", Description: "Buffer for important messages"},
+	}
+
+	result := ParseRings(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRings() = %+v, want %+v", result, expected)
+	}
+	if result[0].Tailable() || !result[1].Tailable() {
+		t.Error("only buffer sinks should be tailable")
+	}
+}
+
+func TestBufferAppend(t *testing.T) {
+	b := Buffer{Max: 3}
+	b.Append("a", "b")
+	b.Append("c", "d", "e")
+
+	if !reflect.DeepEqual(b.Lines, []string{"c", "d", "e"}) {
+		t.Errorf("Lines = %v, want [c d e]", b.Lines)
+	}
+	if b.Dropped != 2 {
+		t.Errorf("Dropped = %d, want 2", b.Dropped)
+	}
+}
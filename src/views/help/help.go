@@ -12,6 +12,7 @@ NAVIGATION
   tab, right, l     Next tab
   shift+tab, left, h Previous tab
   1-9               Jump to tab by number
+  0                 Tab picker: 1-9, then a-h for the tabs past the ninth
   j, down           Move down in table/list
   k, up             Move up in table/list
   g                 Go to top
@@ -28,19 +29,141 @@ STATS TAB (Tab 1)
   e                 Enable selected server
   R                 Set server state to ready
   w                 Set server weight (input popup)
+  W                 Ramp weight to a target over time (W again cancels)
+  m                 Set server/frontend maxconn (input popup)
+  L                 Global maxconn and rate limits menu
+  u                 Undo last state/weight change (with confirmation)
+  O                 Start rolling operation on the row's backend
   x                 Kill sessions (with confirmation)
+  H                 Health/agent checks menu (with confirmation)
   c                 Clear all counters
   s                 Cycle sort column (asc/desc)
 
 INFO TAB (Tab 2)
   /                 Start filtering (type to search)
 
+ERRORS TAB (Tab 3)
+  /                 Filter captures
+  enter             Decode the selected capture, failing byte highlighted
+  x                 Toggle between HTTP text and hex view
+  esc, backspace    Back to the list of captures
+
+MEMORY TAB (Tab 4)
+  /                 Filter pools
+  s                 Cycle sort column (asc/desc)
+  leak?             Pool kept growing over the last 30 refreshes
+
+SESSIONS TAB (Tab 5)
+  /                 Filter sessions
+  s                 Cycle sort column (asc/desc)
+  enter             Show the full dump of the selected session
+  x                 Shut down the selected session (with confirmation)
+  a                 Toggle session analytics (groups, ages, longest-lived)
+  esc, backspace    Back to the list of sessions
+
+CERTS TAB (Tab 6)
+  /                 Filter certificates
+  s                 Cycle sort column (asc/desc)
+  U                 Replace with a local PEM file (preview, then set/commit)
+  C                 Commit a pending certificate transaction (with confirmation)
+  A                 Abort a pending certificate transaction (with confirmation)
+  D                 Hot-load a certificate that changed on disk
+  o                 Toggle the OCSP stapling view (stale responses in red)
+  u                 Update the selected OCSP response (OCSP view)
+  ⚠N in tab bar     N certificates expire within the warning threshold
+
+THREADS TAB (Tab 7)
+  v                 Toggle between the thread table and the raw dump
+  /                 Filter threads
+  ⚠stuck in tab bar A thread is reported stuck (shown in red)
+
+ACTIVITY TAB (Tab 8)
+  d                 Toggle per-thread deltas and totals
+  enter             Order thread columns by the selected metric
+  s                 Cycle sort column
+  v                 Toggle between the matrix and the raw dump
+  /                 Filter metrics
+  Red thread        Doing an imbalanced share of the metric
+
+EVENTS TAB (Tab 9)
+  enter             Follow the selected ring live (show events -w)
+  n                 Follow new events only (show events -w -n)
+  p                 Pause/resume the followed ring
+  /                 Filter sinks, or lines while following
+  esc               Stop following and return to the sinks
+
+ROLLING TAB
+  c                 Continue after a deploy or between servers
+  a                 Abort the operation (with confirmation)
+
+MAPS TAB
+  enter             Open the selected map
+  a                 Add an entry (key, value, confirmation)
+  e                 Set the selected entry's value (with confirmation)
+  x                 Delete the selected entry (with confirmation)
+  C                 Clear all entries (with confirmation)
+  t                 Test a value against the map (get map)
+  F                 Load entries from a local file and show the diff
+  enter             Apply a loaded file atomically (prepare/commit)
+  esc, backspace    Discard a loaded file, or back to the list of maps
+
+ACL TAB
+  enter             Open the selected ACL
+  t                 Test a value against the ACL (get acl)
+  a                 Add a pattern (with confirmation)
+  x                 Delete the selected pattern (with confirmation)
+  C                 Clear all patterns (with confirmation)
+  F                 Load patterns from a local file and show the diff
+  enter             Apply a loaded file atomically (prepare/commit)
+  esc, backspace    Discard a loaded file, or back to the list of ACLs
+
+STICK TABLES TAB
+  enter             Open the selected table
+  s                 Sort by the next data column (top talkers first)
+  f                 Filter on the server side (<type> <op> <value>)
+  F                 Remove the data filter
+  e                 Set a data value of the selected key (with confirmation)
+  x                 Clear the selected key (with confirmation)
+  esc, backspace    Back to the list of tables
+
+CRT-LISTS TAB
+  enter             Open the selected crt-list
+  a                 Add an entry: certificate, [options], SNI filters
+  x                 Delete the selected entry (with confirmation)
+  n                 Create a certificate from a local PEM file
+  esc, backspace    Back to the list of crt-lists
+
+CA/CRL TAB
+  enter             Open the selected CA or CRL file
+  U                 Update from a local PEM file (set/commit, with confirmation)
+  C                 Commit a pending CA/CRL transaction (with confirmation)
+  A                 Abort a pending CA/CRL transaction (with confirmation)
+  esc, backspace    Back to the list of files
+
+TRACES TAB
+  enter             Open the selected source, or change the selected setting
+  S                 Start tracing the source now (with confirmation)
+  X                 Stop tracing the source now (with confirmation)
+  t                 Tail the source's sink live
+  p                 Pause/resume the tail
+  esc, backspace    Back to the settings, then to the sources
+  Leaving the tab   Restores the settings changed in it
+
+AUDIT TAB
+  /                 Filter past actions (user, server, command...)
+  y                 Copy selected command
+  r                 Reload the audit log
+
 FILTER MODE (All Tabs)
   Type to search    Filter servers/backends
   Enter             Apply filter and exit mode
   Esc               Clear filter and exit mode
   Backspace         Delete last character
 
+READ-ONLY MODE
+  --read-only       Disable all actions that change HAProxy state
+  [badge]           Shown next to the socket when actions are restricted
+
 STATUS COLORS
   Green (UP)        Server is healthy and active
   Red (DOWN)        Server is down or unreachable
@@ -55,13 +178,21 @@ ERROR COLORS
 TABS
   1. Stats          Server statistics and control
   2. Info           HAProxy configuration information
-  3. Errors         Error logs
-  4. Memory         Memory pool statistics
-  5. Sessions       Active sessions
-  6. Certs          SSL certificate information
-  7. Threads        Thread information
+  3. Errors         Captured protocol errors, decoded
+  4. Memory         Memory pools, totals and growth
+  5. Sessions       Active sessions, sortable, with per-session shutdown
+  6. Certs          SSL certificates and their expiry
+  7. Threads        Thread states, stuck threads flagged
   8. Activity        System activity metrics
-  9. Events          Event sinks and logs
+  9. Events          Event sinks, followed live
+  Audit             Log of actions sent to HAProxy
+  Rolling           Progress of the rolling operation
+  Maps              Runtime maps and their entries
+  ACL               Runtime ACLs and their patterns
+  Stick Tables      Stick table entries and their counters
+  Crt-lists         crt-list entries, SNI filters and new certificates
+  CA/CRL            CA and CRL files, stale CRLs flagged
+  Traces            Trace sources, their settings and live tail
 
 Press ? or q to close this help screen`
 
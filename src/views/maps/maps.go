@@ -0,0 +1,52 @@
+package maps
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	OpenMap() string
+	PendingUpdate() bool
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	sb.WriteString(baseStyle.Render(m.TableView()))
+	sb.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+	} else if m.TextInputMode() {
+		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: next  esc: cancel)"))
+	} else if m.FilterMode() {
+		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+	} else if open := m.OpenMap(); open != "" {
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(titleStyle.Render(open))
+		sb.WriteString("  ")
+		if m.PendingUpdate() {
+			sb.WriteString(hintStyle.Render("enter: apply atomically  esc: discard  /: filter"))
+		} else {
+			sb.WriteString(hintStyle.Render("a: add  e: set value  x: delete  F: load file  esc: back  /: filter  r: reload"))
+		}
+	} else {
+		sb.WriteString(hintStyle.Render("enter: open map  /: filter  r: reload  ?: help"))
+	}
+}
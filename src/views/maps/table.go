@@ -0,0 +1,122 @@
+package maps
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// InitializeListTable builds the table listing loaded maps.
+func InitializeListTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "ID", Width: 5},
+		{Title: "File", Width: 40},
+		{Title: "Entries", Width: 8},
+		{Title: "Description", Width: 90},
+	})
+}
+
+// InitializeEntriesTable builds the table showing the entries of one map.
+func InitializeEntriesTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Key", Width: 50},
+		{Title: "Value", Width: 80},
+	})
+}
+
+// InitializeDiffTable builds the table comparing the loaded entries with
+// the ones from a local file.
+func InitializeDiffTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Change", Width: 7},
+		{Title: "Key", Width: 45},
+		{Title: "Old", Width: 40},
+		{Title: "New", Width: 40},
+	})
+}
+
+// ParseList parses "show map" (or "show acl") output into ID, File, Entries
+// and Description rows.
+func ParseList(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		// The file is shown in parentheses, empty for anonymous patterns
+		var file string
+		if strings.HasPrefix(rest, "(") {
+			if end := strings.Index(rest, ")"); end > 0 {
+				file = rest[1:end]
+				rest = strings.TrimSpace(rest[end+1:])
+			}
+		}
+
+		// Newer versions append version and entry counters as key=value
+		var entries string
+		var desc []string
+		for _, word := range strings.Fields(rest) {
+			if v, ok := strings.CutPrefix(word, "entry_cnt="); ok {
+				entries = v
+				continue
+			}
+			if strings.HasPrefix(word, "curr_ver=") || strings.HasPrefix(word, "next_ver=") {
+				continue
+			}
+			desc = append(desc, word)
+		}
+
+		rows = append(rows, table.Row{id, file, entries, strings.Join(desc, " ")})
+	}
+	return rows
+}
+
+// ParseEntries parses "show map <map>" output, where each line holds the
+// entry's pointer, key and value, into Key and Value rows. ACL entries have
+// no value.
+func ParseEntries(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !strings.HasPrefix(line, "0x") {
+			continue
+		}
+		_, rest, _ := strings.Cut(line, " ")
+		key, value, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		rows = append(rows, table.Row{key, strings.TrimSpace(value)})
+	}
+	return rows
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
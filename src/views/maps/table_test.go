@@ -0,0 +1,95 @@
+package maps
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []table.Row
+	}{
+		{
+			name: "with counters",
+			input: "# id (file) description\n" +
+				"1 (/etc/haproxy/hosts.map) pattern loaded from file '/etc/haproxy/hosts.map' used by map at file '/etc/haproxy/haproxy.cfg' line 30. curr_ver=0 next_ver=0 entry_cnt=2\n",
+			expected: []table.Row{
+				{"1", "/etc/haproxy/hosts.map", "2", "pattern loaded from file '/etc/haproxy/hosts.map' used by map at file '/etc/haproxy/haproxy.cfg' line 30."},
+			},
+		},
+		{
+			name:  "older format without counters",
+			input: "# id (file) description\n0 (/etc/haproxy/blocklist.acl) pattern loaded from file\n\n",
+			expected: []table.Row{
+				{"0", "/etc/haproxy/blocklist.acl", "", "pattern loaded from file"},
+			},
+		},
+		{
+			name:     "empty",
+			input:    "# id (file) description\n",
+			expected: []table.Row{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRows(t, ParseList(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestParseEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []table.Row
+	}{
+		{
+			name:  "map entries",
+			input: "0x55d1d8e5a7a0 example.com be_example\n0x55d1d8e5a820 api.example.com be_api v2\n",
+			expected: []table.Row{
+				{"example.com", "be_example"},
+				{"api.example.com", "be_api v2"},
+			},
+		},
+		{
+			name:  "acl entries",
+			input: "0x55d1d8e5a7a0 10.0.0.0/8\n",
+			expected: []table.Row{
+				{"10.0.0.0/8", ""},
+			},
+		},
+		{
+			name:     "unknown map",
+			input:    "Unknown map identifier. Please use #<id> or <file>.\n",
+			expected: []table.Row{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRows(t, ParseEntries(tt.input), tt.expected)
+		})
+	}
+}
+
+func assertRows(t *testing.T, result, expected []table.Row) {
+	t.Helper()
+	if len(result) != len(expected) {
+		t.Fatalf("returned %d rows; want %d rows: %q", len(result), len(expected), result)
+	}
+	for i := range result {
+		if len(result[i]) != len(expected[i]) {
+			t.Errorf("Row %d has %d columns; want %d columns", i, len(result[i]), len(expected[i]))
+			continue
+		}
+		for j := range result[i] {
+			if result[i][j] != expected[i][j] {
+				t.Errorf("Row %d, Col %d = %q; want %q", i, j, result[i][j], expected[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package pools
+
+// History holds the allocated bytes of each pool over the last refreshes,
+// oldest first.
+type History map[string][]uint64
+
+// Record appends the allocated bytes of pools, keeping at most max samples
+// per pool and forgetting pools that are gone.
+func (h History) Record(pools []Pool, max int) {
+	seen := make(map[string]bool, len(pools))
+	for _, p := range pools {
+		key := p.Key()
+		seen[key] = true
+		samples := append(h[key], p.AllocatedBytes)
+		if len(samples) > max {
+			samples = samples[len(samples)-max:]
+		}
+		h[key] = samples
+	}
+	for key := range h {
+		if !seen[key] {
+			delete(h, key)
+		}
+	}
+}
+
+// Leaking reports whether a pool looks like it leaks: over its last
+// samples it never shrank and grew in at least half of the intervals.
+func (h History) Leaking(key string, samples int) bool {
+	s := h[key]
+	if samples < 2 || len(s) < samples {
+		return false
+	}
+	s = s[len(s)-samples:]
+	increases := 0
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] < s[i-1]:
+			return false
+		case s[i] > s[i-1]:
+			increases++
+		}
+	}
+	return increases*2 >= len(s)-1
+}
+
+// LeakingPools counts the pools that look like they leak.
+func (h History) LeakingPools(samples int) int {
+	count := 0
+	for key := range h {
+		if h.Leaking(key, samples) {
+			count++
+		}
+	}
+	return count
+}
@@ -1,33 +1,45 @@
 package pools
 
 import (
+	"fmt"
 	"strings"
 
-	"charm.land/bubbles/v2/viewport"
 	"charm.land/lipgloss/v2"
 )
 
 type Model interface {
-	PoolsView() string
-	GetViewport() viewport.Model
-	ViewportFilterMode() bool
-	ViewportFilterInput() string
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	PoolsSort() string
+	PoolTotals() (count int, allocated, used uint64)
+	LeakingPools() int
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	viewport.SetContent(m.PoolsView())
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	sb.WriteString(baseStyle.Render(m.TableView()))
 	sb.WriteString("\n")
 
-	if m.ViewportFilterMode() {
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	if m.FilterMode() {
 		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
-	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		return
 	}
+
+	count, allocated, used := m.PoolTotals()
+	totalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	sb.WriteString(totalStyle.Render(fmt.Sprintf("%d pools, %s allocated, %s used", count, FormatBytes(allocated), FormatBytes(used))))
+	sb.WriteString("  ")
+	if leaking := m.LeakingPools(); leaking > 0 {
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(warnStyle.Render(fmt.Sprintf("%d growing steadily", leaking)))
+		sb.WriteString("  ")
+	}
+	if sort := m.PoolsSort(); sort != "" {
+		sb.WriteString(hintStyle.Render("Sorted by " + sort + "  "))
+	}
+	sb.WriteString(hintStyle.Render("s: sort  /: filter  ?: help"))
 }
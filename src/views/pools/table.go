@@ -0,0 +1,176 @@
+package pools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// Pool is a memory pool as reported by "show pools".
+type Pool struct {
+	Name           string
+	Size           uint64 // bytes per object
+	Allocated      uint64
+	AllocatedBytes uint64
+	Used           uint64
+	Failures       uint64
+	Users          uint64
+}
+
+// Key identifies a pool across refreshes; pools of the same name but
+// different object sizes are distinct.
+func (p Pool) Key() string {
+	return fmt.Sprintf("%s/%d", p.Name, p.Size)
+}
+
+var (
+	poolLine     = regexp.MustCompile(`^-\s*Pool\s+(\S+)\s+\((\d+) bytes\)\s*:\s*(\d+) allocated \((\d+) bytes\),\s*(\d+) used`)
+	poolFailures = regexp.MustCompile(`(\d+) failures`)
+	poolUsers    = regexp.MustCompile(`(\d+) users`)
+)
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Pool", Width: 24},
+		{Title: "Size", Width: 10},
+		{Title: "Allocated", Width: 10},
+		{Title: "Alloc Bytes", Width: 12},
+		{Title: "Used", Width: 10},
+		{Title: "Failures", Width: 9},
+		{Title: "Users", Width: 6},
+		{Title: "Growth", Width: 18},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParsePools parses "show pools" output, one line per pool such as:
+//
+//   - Pool buffer (16384 bytes) : 18 allocated (294912 bytes), 2 used (~1 by thread caches), needed_avg 3, 0 failures, 1 users, @0x55d1 [SHARED]
+func ParsePools(output string) []Pool {
+	var pools []Pool
+	for _, line := range strings.Split(output, "\n") {
+		match := poolLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		p := Pool{
+			Name:           match[1],
+			Size:           parseUint(match[2]),
+			Allocated:      parseUint(match[3]),
+			AllocatedBytes: parseUint(match[4]),
+			Used:           parseUint(match[5]),
+		}
+		if m := poolFailures.FindStringSubmatch(line); m != nil {
+			p.Failures = parseUint(m[1])
+		}
+		if m := poolUsers.FindStringSubmatch(line); m != nil {
+			p.Users = parseUint(m[1])
+		}
+		pools = append(pools, p)
+	}
+	return pools
+}
+
+// Totals sums the allocated and used bytes of pools.
+func Totals(pools []Pool) (allocated, used uint64) {
+	for _, p := range pools {
+		allocated += p.AllocatedBytes
+		used += p.Used * p.Size
+	}
+	return allocated, used
+}
+
+// Rows converts pools to table rows, with the growth of each pool's
+// allocated bytes over the recorded history. Pools with failures are shown
+// in red.
+func Rows(pools []Pool, history History, leakSamples int) []table.Row {
+	alert := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	rows := make([]table.Row, 0, len(pools))
+	for _, p := range pools {
+		failures := fmt.Sprint(p.Failures)
+		if p.Failures > 0 {
+			failures = alert.Render(failures)
+		}
+		rows = append(rows, table.Row{
+			p.Name,
+			FormatBytes(p.Size),
+			fmt.Sprint(p.Allocated),
+			FormatBytes(p.AllocatedBytes),
+			fmt.Sprint(p.Used),
+			failures,
+			fmt.Sprint(p.Users),
+			growth(history, p.Key(), leakSamples),
+		})
+	}
+	return rows
+}
+
+// growth describes the change of a pool's allocated bytes since the
+// previous refresh, flagging a potential leak.
+func growth(history History, key string, leakSamples int) string {
+	samples := history[key]
+	if len(samples) < 2 {
+		return ""
+	}
+	last, previous := samples[len(samples)-1], samples[len(samples)-2]
+	delta := ""
+	switch {
+	case last > previous:
+		delta = "+" + FormatBytes(last-previous)
+	case last < previous:
+		delta = "-" + FormatBytes(previous-last)
+	}
+	if history.Leaking(key, leakSamples) {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true).Render(strings.TrimSpace("leak? " + delta))
+	}
+	return delta
+}
+
+// FormatBytes formats a byte count as "1.5 KB", the form sortable tables
+// understand.
+func FormatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+func parseUint(s string) uint64 {
+	n, _ := strconv.ParseUint(s, 10, 64)
+	return n
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,70 @@
+package pools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePools(t *testing.T) {
+	input := "Dumping pools usage. Use SIGQUIT to flush them.\n" +
+		"  - Pool buffer (16384 bytes) : 18 allocated (294912 bytes), 2 used (~1 by thread caches), needed_avg 3, 0 failures, 1 users, @0x55d1d8e5a7a0 [SHARED]\n" +
+		"  - Pool sc_ctx (2048 bytes) : 3 allocated (6144 bytes), 1 used, needed_avg 0, 2 failures, 2 users, @0x55d1d8e5a820 [SHARED]\n" +
+		"  - Pool cache_st (24 bytes) : 0 allocated (0 bytes), 0 used, 0 failures, 1 users, @0x55d1d8e5a8a0=00 [SHARED]\n" +
+		"Total: 3 pools, 301056 bytes allocated, 34816 used.\n"
+
+	expected := []Pool{
+		{Name: "buffer", Size: 16384, Allocated: 18, AllocatedBytes: 294912, Used: 2, Failures: 0, Users: 1},
+		{Name: "sc_ctx", Size: 2048, Allocated: 3, AllocatedBytes: 6144, Used: 1, Failures: 2, Users: 2},
+		{Name: "cache_st", Size: 24, Allocated: 0, AllocatedBytes: 0, Used: 0, Failures: 0, Users: 1},
+	}
+
+	result := ParsePools(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParsePools() = %+v; want %+v", result, expected)
+	}
+
+	allocated, used := Totals(result)
+	if allocated != 301056 || used != 34816 {
+		t.Errorf("Totals() = %d, %d; want 301056, 34816", allocated, used)
+	}
+}
+
+func TestHistoryLeaking(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []uint64
+		expected bool
+	}{
+		{name: "steady growth", samples: []uint64{100, 200, 200, 300, 400}, expected: true},
+		{name: "shrank once", samples: []uint64{100, 200, 150, 300, 400}, expected: false},
+		{name: "mostly flat", samples: []uint64{100, 100, 100, 100, 200}, expected: false},
+		{name: "too few samples", samples: []uint64{100, 200, 300}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := History{}
+			for _, s := range tt.samples {
+				h.Record([]Pool{{Name: "buffer", Size: 16384, AllocatedBytes: s}}, 5)
+			}
+			if result := h.Leaking("buffer/16384", 5); result != tt.expected {
+				t.Errorf("Leaking(%v) = %v; want %v", tt.samples, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHistoryRecord(t *testing.T) {
+	h := History{}
+	for i := uint64(1); i <= 4; i++ {
+		h.Record([]Pool{{Name: "buffer", Size: 16384, AllocatedBytes: i}, {Name: "trash", Size: 64}}, 3)
+	}
+	if want := []uint64{2, 3, 4}; !reflect.DeepEqual(h["buffer/16384"], want) {
+		t.Errorf("Record() kept %v; want %v", h["buffer/16384"], want)
+	}
+
+	h.Record([]Pool{{Name: "buffer", Size: 16384, AllocatedBytes: 5}}, 3)
+	if _, ok := h["trash/64"]; ok {
+		t.Errorf("Record() kept the history of a pool that is gone")
+	}
+}
@@ -0,0 +1,124 @@
+package rolling
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Progress is a snapshot of a rolling operation for display.
+type Progress struct {
+	Backend  string
+	Servers  []string
+	Steps    []string
+	Current  int
+	Step     int
+	Status   string
+	Elapsed  string
+	Waiting  bool
+	Finished bool
+	Aborted  bool
+	Log      []string
+}
+
+type Model interface {
+	RollingProgress() (Progress, bool)
+	ConfirmMode() bool
+	ConfirmPrompt() string
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	p, ok := m.RollingProgress()
+	if !ok {
+		sb.WriteString(baseStyle.Render("No rolling operation.\n\nSelect a backend or one of its servers in the Stats tab and press O to start one."))
+		sb.WriteString("\n")
+		sb.WriteString(hintStyle.Render("?: help  1-9: jump tabs"))
+		return
+	}
+
+	sb.WriteString(baseStyle.Render(renderPanel(p)))
+	sb.WriteString("\n")
+
+	switch {
+	case m.ConfirmMode():
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+	case p.Finished:
+		sb.WriteString(hintStyle.Render("O (Stats tab): start another operation  ?: help"))
+	case p.Waiting:
+		sb.WriteString(hintStyle.Render("c: continue  a: abort  ?: help"))
+	default:
+		sb.WriteString(hintStyle.Render("a: abort  ?: help"))
+	}
+}
+
+func renderPanel(p Progress) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	currentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var sb strings.Builder
+
+	state := "running"
+	stateStyle := currentStyle
+	switch {
+	case p.Aborted:
+		state, stateStyle = "aborted", failStyle
+	case p.Finished:
+		state, stateStyle = "finished", doneStyle
+	case p.Waiting:
+		state = "waiting for confirmation"
+	}
+	sb.WriteString(titleStyle.Render("Rolling operation on " + p.Backend))
+	sb.WriteString("  " + stateStyle.Render("["+state+"]") + "\n\n")
+
+	// Servers: done, current and pending
+	servers := make([]string, len(p.Servers))
+	for i, s := range p.Servers {
+		switch {
+		case i < p.Current || (i == p.Current && p.Finished && !p.Aborted):
+			servers[i] = doneStyle.Render("✓ " + s)
+		case i == p.Current && p.Aborted:
+			servers[i] = failStyle.Render("✗ " + s)
+		case i == p.Current:
+			servers[i] = currentStyle.Render("▶ " + s)
+		default:
+			servers[i] = dimStyle.Render("· " + s)
+		}
+	}
+	sb.WriteString(labelStyle.Render("Servers  ") + strings.Join(servers, "  ") + "\n")
+
+	// Steps of the current server
+	steps := make([]string, len(p.Steps))
+	for i, s := range p.Steps {
+		switch {
+		case p.Finished && !p.Aborted, i < p.Step:
+			steps[i] = doneStyle.Render(s)
+		case i == p.Step && p.Aborted:
+			steps[i] = failStyle.Render(s)
+		case i == p.Step:
+			steps[i] = currentStyle.Render("[" + s + "]")
+		default:
+			steps[i] = dimStyle.Render(s)
+		}
+	}
+	sb.WriteString(labelStyle.Render("Steps    ") + strings.Join(steps, dimStyle.Render(" → ")) + "\n")
+
+	status := p.Status
+	if !p.Finished && !p.Waiting {
+		status += dimStyle.Render("  (" + p.Elapsed + ")")
+	}
+	sb.WriteString(labelStyle.Render("Status   ") + status + "\n\n")
+
+	sb.WriteString(labelStyle.Render("Log") + "\n")
+	for _, line := range p.Log {
+		sb.WriteString("  " + line + "\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
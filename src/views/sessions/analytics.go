@@ -0,0 +1,98 @@
+package sessions
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Count is the number of sessions sharing a frontend, backend, server or
+// source network.
+type Count struct {
+	Name string
+	N    int
+}
+
+// AgeBucket is one bar of the age histogram.
+type AgeBucket struct {
+	Label string
+	N     int
+}
+
+// LongLived is one of the oldest sessions.
+type LongLived struct {
+	ID       string
+	Source   string
+	Frontend string
+	Backend  string
+	Server   string
+	Age      string
+}
+
+// Analytics summarizes the current sessions for display.
+type Analytics struct {
+	Total      int
+	Frontends  []Count
+	Backends   []Count
+	Servers    []Count
+	Networks   []Count
+	Ages       []AgeBucket
+	LongestAge []LongLived
+}
+
+const barWidth = 40
+
+// RenderAnalytics renders a as the content of the analytics viewport.
+func RenderAnalytics(a Analytics) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("%d sessions", a.Total)) + "\n\n")
+
+	sb.WriteString(labelStyle.Render("Age") + "\n")
+	peak := 0
+	for _, b := range a.Ages {
+		peak = max(peak, b.N)
+	}
+	for _, b := range a.Ages {
+		bar := ""
+		if peak > 0 {
+			bar = strings.Repeat("█", b.N*barWidth/peak)
+		}
+		sb.WriteString(fmt.Sprintf("  %-8s %6d  %s\n", b.Label, b.N, barStyle.Render(bar)))
+	}
+	sb.WriteString("\n")
+
+	groups := []struct {
+		title  string
+		counts []Count
+	}{
+		{"Frontends", a.Frontends},
+		{"Backends", a.Backends},
+		{"Servers", a.Servers},
+		{"Source networks", a.Networks},
+	}
+	for _, g := range groups {
+		sb.WriteString(labelStyle.Render(g.title) + "\n")
+		if len(g.counts) == 0 {
+			sb.WriteString(dimStyle.Render("  none") + "\n")
+		}
+		for _, c := range g.counts {
+			sb.WriteString(fmt.Sprintf("  %6d  %s\n", c.N, c.Name))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(labelStyle.Render("Longest-lived") + "\n")
+	if len(a.LongestAge) == 0 {
+		sb.WriteString(dimStyle.Render("  none") + "\n")
+	}
+	for _, s := range a.LongestAge {
+		sb.WriteString(fmt.Sprintf("  %-8s %-16s %-24s %s → %s/%s\n", s.Age, s.ID, s.Source, s.Frontend, s.Backend, s.Server))
+	}
+	return sb.String()
+}
@@ -9,27 +9,62 @@ import (
 )
 
 type Model interface {
-	SessionsView() string
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	SessionDetail() (id, detail string)
+	SessionsSort() string
+	SessionAnalytics() (Analytics, bool)
 	GetViewport() viewport.Model
 	ViewportFilterMode() bool
 	ViewportFilterInput() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	content := colorize.ColorizeSessionOutput(m.SessionsView())
-	viewport.SetContent(content)
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	id, detail := m.SessionDetail()
+
+	analytics, showAnalytics := m.SessionAnalytics()
+
+	if showAnalytics {
+		viewport := m.GetViewport()
+		viewport.SetContent(RenderAnalytics(analytics))
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	} else if id != "" {
+		viewport := m.GetViewport()
+		viewport.SetContent(colorize.ColorizeSessionOutput(detail))
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	} else {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	}
 	sb.WriteString("\n")
 
-	if m.ViewportFilterMode() {
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+	} else if m.FilterMode() {
+		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+	} else if m.ViewportFilterMode() {
 		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+	} else if showAnalytics {
+		sb.WriteString(hintStyle.Render("j/k: scroll  a: back to sessions  (refreshes with the session list)"))
+	} else if id != "" {
+		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(titleStyle.Render("Session " + id))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("j/k: scroll  x: shutdown  r: reload  esc: back"))
 	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		if sort := m.SessionsSort(); sort != "" {
+			sb.WriteString(hintStyle.Render("Sorted by " + sort + "  "))
+		}
+		sb.WriteString(hintStyle.Render("enter: details  x: shutdown  s: sort  a: analytics  /: filter  ?: help"))
 	}
 }
@@ -0,0 +1,115 @@
+package sessions
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// Stream connector states, as numbered in the scf/scb fields of HAProxy 2.6
+// and later.
+var connectorStates = []string{"INI", "REQ", "QUE", "TAR", "ASS", "CON", "CER", "RDY", "EST", "DIS", "CLO"}
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "ID", Width: 16},
+		{Title: "Proto", Width: 7},
+		{Title: "Source", Width: 24},
+		{Title: "Frontend", Width: 16},
+		{Title: "Backend", Width: 16},
+		{Title: "Server", Width: 16},
+		{Title: "Age", Width: 8},
+		{Title: "State", Width: 6},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParseSessions parses "show sess" output, one session per line such as
+//
+//	0x55c4d5b7c000: proto=tcpv4 src=10.0.0.1:50874 fe=http be=app srv=app1 ts=00 epoch=0 age=5s calls=3 ... scf=[8,0h,fd=14,rex=,wex=] scb=[8,1h,fd=15,rex=,wex=] exp=1m
+//
+// into ID, Proto, Source, Frontend, Backend, Server, Age and State rows.
+// State is the backend side connector state. There is no idle column, as
+// "show sess" doesn't report idle time.
+func ParseSessions(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "0x") {
+			continue
+		}
+		id, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		fields := map[string]string{}
+		for _, word := range strings.Fields(rest) {
+			name, value, ok := strings.Cut(word, "=")
+			if !ok {
+				continue
+			}
+			fields[name] = value
+		}
+
+		rows = append(rows, table.Row{
+			id,
+			fields["proto"],
+			fields["src"],
+			fields["fe"],
+			fields["be"],
+			fields["srv"],
+			fields["age"],
+			connectorState(fields),
+		})
+	}
+	return rows
+}
+
+// connectorState names the backend side state from "scb=[8,...]". Older
+// versions report it as "s1=[7,...]" with a different numbering, shown as
+// is.
+func connectorState(fields map[string]string) string {
+	if scb, ok := fields["scb"]; ok {
+		n := stateNumber(scb)
+		if i, err := strconv.Atoi(n); err == nil && i >= 0 && i < len(connectorStates) {
+			return connectorStates[i]
+		}
+		return n
+	}
+	return stateNumber(fields["s1"])
+}
+
+// stateNumber returns the first element of a "[8,1h,fd=15,...]" field.
+func stateNumber(field string) string {
+	field = strings.TrimPrefix(field, "[")
+	n, _, _ := strings.Cut(field, ",")
+	return n
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
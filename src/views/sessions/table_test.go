@@ -0,0 +1,46 @@
+package sessions
+
+import (
+	"reflect"
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseSessions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []table.Row
+	}{
+		{
+			name: "current format",
+			input: "0x55c4d5b7c000: proto=tcpv4 src=10.0.0.1:50874 fe=http be=app srv=app1 ts=00 epoch=0 age=5s calls=3 rate=0 cpu=0 lat=0 rq[f=848000h,i=0,an=00h,ax=] rp[f=80048000h,i=0,an=00h,ax=] scf=[8,0h,fd=14,rex=,wex=] scb=[8,1h,fd=15,rex=,wex=] exp=1m rc=0 c_exp=\n" +
+				"0x55c4d5b7d000: proto=unix_stream src=unix:1 fe=GLOBAL be=<NONE> srv=<none> ts=00 epoch=0 age=0s calls=2 rate=2 cpu=0 lat=0 rq[f=c4c020h,i=0,an=00h,ax=] rp[f=80008000h,i=0,an=00h,ax=] scf=[8,280h,fd=16,rex=10s,wex=] scb=[8,1h,fd=-1,rex=,wex=] exp=10s rc=0 c_exp=\n",
+			expected: []table.Row{
+				{"0x55c4d5b7c000", "tcpv4", "10.0.0.1:50874", "http", "app", "app1", "5s", "EST"},
+				{"0x55c4d5b7d000", "unix_stream", "unix:1", "GLOBAL", "<NONE>", "<none>", "0s", "EST"},
+			},
+		},
+		{
+			name:  "older format",
+			input: "0x1f2e3d0: proto=tcpv4 src=10.0.0.2:41000 fe=web be=app srv=app2 ts=08 age=1h02m calls=4 rq[f=909202h,i=0,an=00h,rx=,wx=,ax=] rp[f=109220h,i=0,an=00h,rx=,wx=,ax=] s0=[7,8h,fd=12,ex=] s1=[7,118h,fd=13,ex=] exp=\n",
+			expected: []table.Row{
+				{"0x1f2e3d0", "tcpv4", "10.0.0.2:41000", "web", "app", "app2", "1h02m", "7"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseSessions(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseSessions() = %q; want %q", result, tt.expected)
+			}
+		})
+	}
+}
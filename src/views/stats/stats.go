@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -18,9 +19,20 @@ type Model interface {
 	SortAscending() bool
 	ConfirmMode() bool
 	ConfirmPrompt() string
-	WeightMode() bool
-	WeightInput() string
-	WeightServer() string
+	NumericMode() bool
+	NumericInput() string
+	NumericPrompt() string
+	NumericBounds() string
+	LimitsMode() bool
+	LimitsHint() string
+	ChecksMode() bool
+	ChecksHint() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	UndoDepth() int
+	RampCount() int
+	ActionsDisabledReason() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
@@ -31,12 +43,24 @@ func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
 	if m.ConfirmMode() {
 		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
 		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
-	} else if m.WeightMode() {
-		weightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-		sb.WriteString(weightStyle.Render("Weight for " + m.WeightServer() + ": " + m.WeightInput() + "█"))
+	} else if m.ChecksMode() {
+		menuStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(menuStyle.Render("Checks: " + m.ChecksHint()))
+	} else if m.TextInputMode() {
+		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
 		sb.WriteString(" ")
 		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("(0-256  enter: apply  esc: cancel)"))
+		sb.WriteString(hintStyle.Render("(enter: confirm  esc: cancel)"))
+	} else if m.LimitsMode() {
+		menuStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(menuStyle.Render("Global limits: " + m.LimitsHint()))
+	} else if m.NumericMode() {
+		inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		sb.WriteString(inputStyle.Render(m.NumericPrompt() + ": " + m.NumericInput() + "█"))
+		sb.WriteString(" ")
+		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+		sb.WriteString(hintStyle.Render("(" + m.NumericBounds() + "  enter: confirm  esc: cancel)"))
 	} else if m.FilterMode() {
 		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
@@ -45,7 +69,19 @@ func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
 	} else {
 		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		hint := "d: disable  D: drain  e: enable  R: ready  w: weight  s: sort  /: filter  ?: help"
+		hint := "d: disable  D: drain  e: enable  R: ready  w: weight  W: ramp  m: maxconn  L: limits  H: checks  s: sort  /: filter  ?: help"
+		if n := m.RampCount(); n > 0 {
+			hint += "  " + strconv.Itoa(n) + " ramp(s) running, W: cancel"
+		}
+		if n := m.UndoDepth(); n > 0 {
+			hint += "  u: undo (" + strconv.Itoa(n) + ")"
+		}
+		if reason := m.ActionsDisabledReason(); reason != "" {
+			// Show only the read-only keys and explain why actions are off
+			hint = "s: sort  /: filter  ?: help  "
+			disabledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+			hint += disabledStyle.Render("actions disabled: " + reason)
+		}
 		if col := m.SortColumn(); col >= 0 {
 			cols := tbl.Columns()
 			if col < len(cols) {
@@ -21,7 +21,9 @@ func InitializeTable() table.Model {
 		{Title: "Bytes Out", Width: 10},
 		{Title: "Rate/s", Width: 7},
 		{Title: "Errors", Width: 7},
-		{Title: "Weight", Width: 7},
+		{Title: "Weight", Width: 9},
+		{Title: "Check", Width: 8},
+		{Title: "Agent", Width: 8},
 	}
 
 	t := table.New(
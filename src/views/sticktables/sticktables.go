@@ -0,0 +1,53 @@
+package sticktables
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	NumericMode() bool
+	NumericPrompt() string
+	NumericInput() string
+	NumericBounds() string
+	OpenStickTable() string
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	sb.WriteString(baseStyle.Render(m.TableView()))
+	sb.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+	} else if m.TextInputMode() {
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: next  esc: cancel)"))
+	} else if m.NumericMode() {
+		sb.WriteString(inputStyle.Render(m.NumericPrompt() + ": " + m.NumericInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(" + m.NumericBounds() + "  enter: confirm  esc: cancel)"))
+	} else if m.FilterMode() {
+		sb.WriteString(inputStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+	} else if open := m.OpenStickTable(); open != "" {
+		sb.WriteString(inputStyle.Render(open))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("s: sort  f: data filter  F: clear data filter  e: set data  x: clear key  esc: back  /: filter  r: reload"))
+	} else {
+		sb.WriteString(hintStyle.Render("enter: open table  /: filter  r: reload  ?: help"))
+	}
+}
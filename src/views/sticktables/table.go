@@ -0,0 +1,172 @@
+package sticktables
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// FixedColumns is the number of columns before the data columns of an
+// entries table: Key, Use and Exp.
+const FixedColumns = 3
+
+// InitializeListTable builds the table listing stick tables.
+func InitializeListTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Table", Width: 30},
+		{Title: "Type", Width: 10},
+		{Title: "Size", Width: 10},
+		{Title: "Used", Width: 10},
+	})
+}
+
+// InitializeEntriesTable builds the table showing the entries of a stick
+// table, with one column per stored data type.
+func InitializeEntriesTable(dataColumns []string) table.Model {
+	columns := []table.Column{
+		{Title: "Key", Width: 40},
+		{Title: "Use", Width: 5},
+		{Title: "Exp", Width: 10},
+	}
+	for _, name := range dataColumns {
+		columns = append(columns, table.Column{Title: name, Width: max(len(name)+2, 10)})
+	}
+	return newTable(columns)
+}
+
+// ParseList parses "show table" output, one "# table: <name>, type: <type>,
+// size:<size>, used:<used>" header per table, into Table, Type, Size and
+// Used rows.
+func ParseList(output string) []table.Row {
+	var rows []table.Row
+	for _, line := range strings.Split(output, "\n") {
+		fields := parseHeader(line)
+		if fields == nil {
+			continue
+		}
+		rows = append(rows, table.Row{fields["table"], fields["type"], fields["size"], fields["used"]})
+	}
+	return rows
+}
+
+// ParseEntries parses "show table <name>" output into Key, Use and Exp rows
+// followed by the data columns, returned in the order they first appear.
+// A line looks like:
+//
+//	0x55e3f4e6f2d0: key=10.0.0.1 use=0 exp=29887 shard=0 gpc0=0 conn_rate(10000)=1
+func ParseEntries(output string) (rows []table.Row, dataColumns []string) {
+	type entry struct {
+		key, use, exp string
+		data          map[string]string
+	}
+	var entries []entry
+	index := map[string]bool{}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "0x") {
+			continue
+		}
+		_, rest, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		e := entry{data: map[string]string{}}
+		for _, field := range strings.Fields(rest) {
+			name, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch name {
+			case "key":
+				e.key = value
+			case "use":
+				e.use = value
+			case "exp":
+				e.exp = value
+			case "shard", "server_id", "server_key", "server_name":
+				// Bookkeeping rather than counters
+			default:
+				name = dataColumn(name)
+				if !index[name] {
+					index[name] = true
+					dataColumns = append(dataColumns, name)
+				}
+				e.data[name] = value
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	for _, e := range entries {
+		row := table.Row{e.key, e.use, e.exp}
+		for _, name := range dataColumns {
+			row = append(row, e.data[name])
+		}
+		rows = append(rows, row)
+	}
+	return rows, dataColumns
+}
+
+// dataColumn names the column of a stored data type. Rates carry their
+// period, dropped to group them under their type as in "conn_rate(10000)";
+// array types keep their index, as in "gpc(1)" or "gpc_rate(1,10000)".
+func dataColumn(name string) string {
+	base, args, ok := strings.Cut(name, "(")
+	if !ok || !strings.HasSuffix(base, "_rate") {
+		return name
+	}
+	if index, _, isArray := strings.Cut(strings.TrimSuffix(args, ")"), ","); isArray {
+		return base + "(" + index + ")"
+	}
+	return base
+}
+
+// parseHeader parses a "# table: ..." line into its comma-separated
+// name: value fields, or returns nil for other lines.
+func parseHeader(line string) map[string]string {
+	line = strings.TrimSpace(line)
+	rest, ok := strings.CutPrefix(line, "# table:")
+	if !ok {
+		return nil
+	}
+	fields := map[string]string{}
+	for i, part := range strings.Split("table:"+rest, ",") {
+		name, value, _ := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if i > 0 && name == "" {
+			continue
+		}
+		fields[name] = strings.TrimSpace(value)
+	}
+	return fields
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,76 @@
+package sticktables
+
+import (
+	"reflect"
+	"testing"
+
+	"charm.land/bubbles/v2/table"
+)
+
+func TestParseList(t *testing.T) {
+	input := "# table: front_pub, type: ip, size:204800, used:171\n" +
+		"# table: back_rdp, type: string, size:1024, used:0\n"
+	expected := []table.Row{
+		{"front_pub", "ip", "204800", "171"},
+		{"back_rdp", "string", "1024", "0"},
+	}
+
+	result := ParseList(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseList() = %q; want %q", result, expected)
+	}
+}
+
+func TestParseEntries(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedRows    []table.Row
+		expectedColumns []string
+	}{
+		{
+			name: "rates and counters",
+			input: "# table: http, type: ip, size:1048576, used:2\n" +
+				"0x55e3f4e6f2d0: key=10.0.0.1 use=0 exp=29887 shard=0 gpc0=3 conn_rate(10000)=1 http_req_rate(10000)=12\n" +
+				"0x55e3f4e6f3a0: key=10.0.0.2 use=1 exp=12000 shard=0 gpc0=0 conn_rate(10000)=4 http_req_rate(10000)=250\n",
+			expectedRows: []table.Row{
+				{"10.0.0.1", "0", "29887", "3", "1", "12"},
+				{"10.0.0.2", "1", "12000", "0", "4", "250"},
+			},
+			expectedColumns: []string{"gpc0", "conn_rate", "http_req_rate"},
+		},
+		{
+			name: "array types",
+			input: "# table: http, type: ip, size:1048576, used:1\n" +
+				"0x55e3f4e6f2d0: key=10.0.0.1 use=0 exp=29887 shard=0 gpc(0)=3 gpc(1)=7 gpc_rate(0,10000)=1 gpc_rate(1,10000)=2\n",
+			expectedRows: []table.Row{
+				{"10.0.0.1", "0", "29887", "3", "7", "1", "2"},
+			},
+			expectedColumns: []string{"gpc(0)", "gpc(1)", "gpc_rate(0)", "gpc_rate(1)"},
+		},
+		{
+			name: "server stickiness",
+			input: "# table: be_app, type: ip, size:1024, used:1\n" +
+				"0x55e3f4e6f2d0: key=10.0.0.1 use=0 exp=1000 server_id=2 server_key=app2\n",
+			expectedRows: []table.Row{
+				{"10.0.0.1", "0", "1000"},
+			},
+		},
+		{
+			name:  "empty table",
+			input: "# table: http, type: ip, size:1048576, used:0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, columns := ParseEntries(tt.input)
+			if !reflect.DeepEqual(rows, tt.expectedRows) {
+				t.Errorf("ParseEntries() rows = %q; want %q", rows, tt.expectedRows)
+			}
+			if !reflect.DeepEqual(columns, tt.expectedColumns) {
+				t.Errorf("ParseEntries() columns = %q; want %q", columns, tt.expectedColumns)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package threads
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+	"github.com/knowald/lazyhap/src/views/activity"
+)
+
+const defaultTableHeight = 20
+
+// Thread is the state of a thread as reported by "show threads".
+type Thread struct {
+	ID       int
+	Calling  bool // the thread that ran "show threads"
+	Stuck    bool // HAProxy's watchdog considers the thread stuck
+	Active   bool // the thread has work to do
+	Harmless bool
+	Prof     uint64
+	CPUNs    uint64
+	Task     string // function of the task running, "" when idle
+}
+
+var (
+	threadHeader = regexp.MustCompile(`^(\*)?\s*(>)?\s*Thread\s+(\d+)\s*:(.*)$`)
+	threadField  = regexp.MustCompile(`(\w+)=(\S+)`)
+	taskFunction = regexp.MustCompile(`\(([A-Za-z_][\w.]*)\)`)
+)
+
+func InitializeTable() table.Model {
+	columns := []table.Column{
+		{Title: "Thread", Width: 7},
+		{Title: "State", Width: 9},
+		{Title: "Stuck", Width: 6},
+		{Title: "Prof", Width: 10},
+		{Title: "Loops", Width: 12},
+		{Title: "Wake-ups", Width: 12},
+		{Title: "CPU", Width: 10},
+		{Title: "Current Task", Width: 30},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+// ParseThreads parses "show threads" output. Each thread starts with a
+// header line, prefixed with "*" for the calling thread and ">" for a
+// stuck one:
+//
+//	*>Thread 2 : id=0x7f4e act=1 glob=0 wq=1 rq=0 tl=1 tlsz=0 rqsz=1
+//	      1/2    stuck=1 prof=0 harmless=0 wantrdv=0
+//	             cpu_ns: poll=1095327 now=2098197 diff=1002870
+//	             curr_task=0x7f4e0c00b3a0 (task) calls=1 last=0
+//	               fct=0x55e3d4c1a2b0(process_stream) ctx=0x7f4e0c00a8c0
+func ParseThreads(output string) []Thread {
+	var threads []Thread
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := threadHeader.FindStringSubmatch(trimmed); match != nil {
+			id, _ := strconv.Atoi(match[3])
+			threads = append(threads, Thread{ID: id, Calling: match[1] != "", Stuck: match[2] != ""})
+			trimmed = match[4]
+		}
+		if len(threads) == 0 {
+			continue
+		}
+		t := &threads[len(threads)-1]
+
+		if strings.HasPrefix(trimmed, "fct=") {
+			if m := taskFunction.FindStringSubmatch(trimmed); m != nil {
+				t.Task = m[1]
+			}
+			continue
+		}
+		for _, field := range threadField.FindAllStringSubmatch(trimmed, -1) {
+			switch field[1] {
+			case "act":
+				t.Active = field[2] != "0"
+			case "stuck":
+				t.Stuck = t.Stuck || field[2] != "0"
+			case "harmless":
+				t.Harmless = field[2] != "0"
+			case "prof":
+				t.Prof, _ = strconv.ParseUint(field[2], 10, 64)
+			case "now":
+				if strings.HasPrefix(trimmed, "cpu_ns:") {
+					t.CPUNs, _ = strconv.ParseUint(field[2], 10, 64)
+				}
+			}
+		}
+	}
+	return threads
+}
+
+// Stuck returns the IDs of the stuck threads.
+func Stuck(threads []Thread) []int {
+	var ids []int
+	for _, t := range threads {
+		if t.Stuck {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// Rows converts threads to table rows. Loops and wake-ups come from the
+// "show activity" counters; prof counters that grew since previous are
+// highlighted, as are stuck threads.
+func Rows(threads, previous []Thread, metrics []activity.Metric) []table.Row {
+	alert := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	warn := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	loops, _ := activity.Lookup(metrics, "loops")
+	wakeups, _ := activity.Lookup(metrics, "wake_tasks")
+
+	before := make(map[int]Thread, len(previous))
+	for _, t := range previous {
+		before[t.ID] = t
+	}
+
+	rows := make([]table.Row, 0, len(threads))
+	for _, t := range threads {
+		id := strconv.Itoa(t.ID)
+		if t.Calling {
+			id += "*"
+		}
+		state := "idle"
+		if t.Active {
+			state = "running"
+		}
+		stuck := "no"
+		if t.Stuck {
+			state, stuck = alert.Render("stuck"), alert.Render("yes")
+		}
+		prof := strconv.FormatUint(t.Prof, 10)
+		if p, ok := before[t.ID]; ok && t.Prof > p.Prof {
+			prof = warn.Render(fmt.Sprintf("%s +%d", prof, t.Prof-p.Prof))
+		}
+		task := t.Task
+		if task == "" {
+			task = "-"
+		}
+		rows = append(rows, table.Row{
+			id,
+			state,
+			stuck,
+			prof,
+			threadValue(loops, t.ID),
+			threadValue(wakeups, t.ID),
+			fmt.Sprintf("%.1fs", float64(t.CPUNs)/1e9),
+			task,
+		})
+	}
+	return rows
+}
+
+// threadValue returns the value of metric for thread id, counted from 1.
+func threadValue(metric activity.Metric, id int) string {
+	if id < 1 || id > len(metric.Threads) {
+		return ""
+	}
+	return strconv.FormatUint(metric.Threads[id-1], 10)
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
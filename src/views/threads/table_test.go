@@ -0,0 +1,54 @@
+package threads
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/knowald/lazyhap/src/views/activity"
+)
+
+const sample = "  Thread 1 : id=0x7f4f8a7fe700 act=0 glob=0 wq=1 rq=0 tl=0 tlsz=0 rqsz=0\n" +
+	"      1/1    stuck=0 prof=0 harmless=1 wantrdv=0\n" +
+	"             cpu_ns: poll=1095327 now=1500000000 diff=2870\n" +
+	"             curr_task=0\n" +
+	"*>Thread 2 : id=0x7f4f89ffd700 act=1 glob=0 wq=1 rq=0 tl=1 tlsz=0 rqsz=1\n" +
+	"      1/2    stuck=1 prof=12 harmless=0 wantrdv=0\n" +
+	"             cpu_ns: poll=1095327 now=98000000000 diff=1002870\n" +
+	"             curr_task=0x7f4e0c00b3a0 (task) calls=1 last=0\n" +
+	"               fct=0x55e3d4c1a2b0(process_stream) ctx=0x7f4e0c00a8c0\n"
+
+func TestParseThreads(t *testing.T) {
+	expected := []Thread{
+		{ID: 1, Harmless: true, CPUNs: 1500000000},
+		{ID: 2, Calling: true, Stuck: true, Active: true, Prof: 12, CPUNs: 98000000000, Task: "process_stream"},
+	}
+
+	result := ParseThreads(sample)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseThreads() = %+v; want %+v", result, expected)
+	}
+	if stuck := Stuck(result); !reflect.DeepEqual(stuck, []int{2}) {
+		t.Errorf("Stuck() = %v; want [2]", stuck)
+	}
+}
+
+func TestRows(t *testing.T) {
+	current := ParseThreads(sample)
+	previous := []Thread{{ID: 1}, {ID: 2, Prof: 10}}
+	metrics := []activity.Metric{
+		{Name: "loops", Total: 300, Threads: []uint64{100, 200}},
+		{Name: "wake_tasks", Total: 30, Threads: []uint64{10, 20}},
+	}
+
+	rows := Rows(current, previous, metrics)
+	if len(rows) != 2 {
+		t.Fatalf("Rows() returned %d rows; want 2", len(rows))
+	}
+	if want := []string{"1", "idle", "no", "0", "100", "10", "1.5s", "-"}; !reflect.DeepEqual([]string(rows[0]), want) {
+		t.Errorf("Rows()[0] = %q; want %q", rows[0], want)
+	}
+	if rows[1][0] != "2*" || !strings.Contains(rows[1][1], "stuck") || !strings.Contains(rows[1][3], "+2") || rows[1][7] != "process_stream" {
+		t.Errorf("Rows()[1] = %q", rows[1])
+	}
+}
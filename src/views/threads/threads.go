@@ -1,6 +1,7 @@
 package threads
 
 import (
+	"fmt"
 	"strings"
 
 	"charm.land/bubbles/v2/viewport"
@@ -9,27 +10,56 @@ import (
 )
 
 type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
 	ThreadsView() string
+	RawThreads() bool
+	StuckThreads() []int
 	GetViewport() viewport.Model
 	ViewportFilterMode() bool
 	ViewportFilterInput() string
 }
 
 func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
-	viewport := m.GetViewport()
-	content := colorize.ColorizeThreadOutput(m.ThreadsView())
-	viewport.SetContent(content)
-	sb.WriteString(baseStyle.Render(viewport.View()))
+	raw := m.RawThreads()
+	if raw {
+		viewport := m.GetViewport()
+		content := colorize.ColorizeThreadOutput(m.ThreadsView())
+		viewport.SetContent(content)
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	} else {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	}
 	sb.WriteString("\n")
 
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 	if m.ViewportFilterMode() {
-		filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
 		sb.WriteString(filterStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
 		sb.WriteString(" ")
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+	if m.FilterMode() {
+		sb.WriteString(filterStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+
+	if stuck := m.StuckThreads(); len(stuck) > 0 {
+		ids := make([]string, len(stuck))
+		for i, id := range stuck {
+			ids[i] = fmt.Sprint(id)
+		}
+		alertStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(alertStyle.Render("Stuck: thread " + strings.Join(ids, ", ")))
+		sb.WriteString("  ")
+	}
+	if raw {
+		sb.WriteString(hintStyle.Render("j/k: scroll  v: table  /: filter  ?: help"))
 	} else {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-		sb.WriteString(hintStyle.Render("j/k: scroll  /: filter  ?: help  1-9: jump tabs"))
+		sb.WriteString(hintStyle.Render("v: raw dump  /: filter  ?: help  (* marks the calling thread)"))
 	}
 }
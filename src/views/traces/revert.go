@@ -0,0 +1,37 @@
+package traces
+
+// Snapshot is the state and settings of a source before it was changed,
+// to restore them.
+type Snapshot struct {
+	Source   string
+	State    string
+	Settings []Setting
+}
+
+// RevertCommands returns the "trace" commands restoring the source to s.
+// A source that was not running is stopped first, so restoring its start
+// events leaves it waiting as before; one that was running is started last.
+// Event sets are cleared then enabled one event at a time.
+func (s Snapshot) RevertCommands() []string {
+	prefix := "trace " + s.Source + " "
+	var cmds []string
+	if s.State != StateRunning {
+		cmds = append(cmds, prefix+"stop now")
+	}
+	for _, setting := range s.Settings {
+		if !setting.IsEventSet() {
+			if setting.Value() != "" {
+				cmds = append(cmds, prefix+setting.Kind+" "+setting.Value())
+			}
+			continue
+		}
+		cmds = append(cmds, prefix+setting.Kind+" none")
+		for _, event := range setting.Current {
+			cmds = append(cmds, prefix+setting.Kind+" "+event)
+		}
+	}
+	if s.State == StateRunning {
+		cmds = append(cmds, prefix+"start now")
+	}
+	return cmds
+}
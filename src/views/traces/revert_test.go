@@ -0,0 +1,48 @@
+package traces
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRevertCommands(t *testing.T) {
+	settings := []Setting{
+		{Kind: "sink", Current: []string{"none"}},
+		{Kind: "level", Current: []string{"user"}},
+		{Kind: "event", Current: []string{"h1c_new", "rx_data"}},
+		{Kind: "stop"},
+	}
+
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+		expected []string
+	}{
+		{
+			name:     "stopped source",
+			snapshot: Snapshot{Source: "h1", State: StateStopped, Settings: settings},
+			expected: []string{
+				"trace h1 stop now",
+				"trace h1 sink none",
+				"trace h1 level user",
+				"trace h1 event none",
+				"trace h1 event h1c_new",
+				"trace h1 event rx_data",
+				"trace h1 stop none",
+			},
+		},
+		{
+			name:     "running source without recorded settings",
+			snapshot: Snapshot{Source: "h2", State: StateRunning},
+			expected: []string{"trace h2 start now"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.snapshot.RevertCommands(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("RevertCommands() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
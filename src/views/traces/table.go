@@ -0,0 +1,200 @@
+package traces
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/table"
+	"charm.land/lipgloss/v2"
+)
+
+const defaultTableHeight = 20
+
+// Trace states, as reported by "trace".
+const (
+	StateStopped = "stopped"
+	StateWaiting = "waiting"
+	StateRunning = "running"
+)
+
+// Kinds are the settings of a source shown by "trace <source> <kind>".
+// Sink, level, verbosity and lock have one value; event, start and stop
+// are sets of events.
+var Kinds = []string{"sink", "level", "verbosity", "lock", "event", "start", "stop"}
+
+// Source is a trace source as listed by "trace".
+type Source struct {
+	Name        string
+	State       string
+	Description string
+}
+
+// Option is a line of "trace" output: a mark, a name and a description,
+// as in "  * error      : report errors" or "  [R] h1         : HTTP/1".
+type Option struct {
+	Mark        string
+	Name        string
+	Description string
+}
+
+// Setting is the value of a source's setting with the choices HAProxy
+// offers for it.
+type Setting struct {
+	Kind    string
+	Current []string // the selected choice, or the enabled events
+	Choices []string
+}
+
+// InitializeSourcesTable builds the table listing the trace sources.
+func InitializeSourcesTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Source", Width: 16},
+		{Title: "State", Width: 10},
+		{Title: "Description", Width: 60},
+	})
+}
+
+// InitializeSettingsTable builds the table showing the settings of a
+// source.
+func InitializeSettingsTable() table.Model {
+	return newTable([]table.Column{
+		{Title: "Setting", Width: 10},
+		{Title: "Current", Width: 30},
+		{Title: "Choices", Width: 70},
+	})
+}
+
+// ParseOptions parses the option lines of "trace" output, skipping the
+// header.
+func ParseOptions(output string) []Option {
+	var options []Option
+	for _, line := range strings.Split(output, "\n") {
+		head, description, ok := strings.Cut(line, " : ")
+		if !ok || !strings.HasPrefix(head, "  ") {
+			continue
+		}
+		head = strings.TrimSpace(head)
+		var o Option
+		if strings.HasPrefix(head, "[") && len(head) > 3 && head[2] == ']' {
+			o.Mark, o.Name = head[1:2], strings.TrimSpace(head[3:])
+		} else if mark, name, ok := strings.Cut(head, " "); ok && len(mark) == 1 {
+			o.Mark, o.Name = mark, strings.TrimSpace(name)
+		} else {
+			o.Name = head
+		}
+		o.Description = strings.TrimSpace(description)
+		options = append(options, o)
+	}
+	return options
+}
+
+// ParseSources parses "trace" output into the trace sources:
+//
+//	Supported trace sources and states (.=stopped, w=waiting, R=running) :
+//	  [.] 0          : not a source, will immediately stop all traces
+//	  [R] h1         : HTTP/1 multiplexer
+func ParseSources(output string) []Source {
+	var sources []Source
+	for _, o := range ParseOptions(output) {
+		if o.Name == "0" {
+			continue
+		}
+		state := StateStopped
+		switch o.Mark {
+		case "w":
+			state = StateWaiting
+		case "R":
+			state = StateRunning
+		}
+		sources = append(sources, Source{Name: o.Name, State: state, Description: o.Description})
+	}
+	return sources
+}
+
+// ParseSetting parses "trace <source> <kind>" output. Choices marked with
+// "*" are current; events marked with "+" are enabled.
+func ParseSetting(kind, output string) Setting {
+	s := Setting{Kind: kind}
+	for _, o := range ParseOptions(output) {
+		s.Choices = append(s.Choices, o.Name)
+		if o.Mark == "*" || o.Mark == "+" {
+			s.Current = append(s.Current, o.Name)
+		}
+	}
+	return s
+}
+
+// IsEventSet reports whether the setting is a set of events rather than a
+// single choice.
+func (s Setting) IsEventSet() bool {
+	return s.Kind == "event" || s.Kind == "start" || s.Kind == "stop"
+}
+
+// Value returns the current choice, or the enabled events.
+func (s Setting) Value() string {
+	return strings.Join(s.Current, " ")
+}
+
+// Lookup returns the setting of the given kind.
+func Lookup(settings []Setting, kind string) (Setting, bool) {
+	for _, s := range settings {
+		if s.Kind == kind {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// SourceRows converts sources to Source, State and Description rows, with
+// running sources in green and waiting ones in yellow.
+func SourceRows(sources []Source) []table.Row {
+	rows := make([]table.Row, 0, len(sources))
+	for _, s := range sources {
+		rows = append(rows, table.Row{s.Name, colorState(s.State), s.Description})
+	}
+	return rows
+}
+
+// SettingRows converts settings to Setting, Current and Choices rows.
+func SettingRows(settings []Setting) []table.Row {
+	rows := make([]table.Row, 0, len(settings))
+	for _, s := range settings {
+		rows = append(rows, table.Row{s.Kind, s.Value(), strings.Join(s.Choices, " ")})
+	}
+	return rows
+}
+
+func colorState(state string) string {
+	switch state {
+	case StateRunning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render(state)
+	case StateWaiting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render(state)
+	}
+	return state
+}
+
+func newTable(columns []table.Column) table.Model {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(defaultTableHeight),
+	)
+
+	t.SetStyles(tableStyles())
+	return t
+}
+
+func tableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("205"))
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	return s
+}
@@ -0,0 +1,74 @@
+package traces
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSources(t *testing.T) {
+	input := "Supported trace sources and states (.=stopped, w=waiting, R=running) :\n" +
+		"  [.] 0          : not a source, will immediately stop all traces\n" +
+		"  [R] h1         : HTTP/1 multiplexer\n" +
+		"  [w] h2         : HTTP/2 multiplexer\n" +
+		"  [.] stream     : Applicative stream\n"
+	expected := []Source{
+		{Name: "h1", State: StateRunning, Description: "HTTP/1 multiplexer"},
+		{Name: "h2", State: StateWaiting, Description: "HTTP/2 multiplexer"},
+		{Name: "stream", State: StateStopped, Description: "Applicative stream"},
+	}
+
+	if result := ParseSources(input); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseSources() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestParseSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		input    string
+		expected Setting
+	}{
+		{
+			name: "single choice",
+			kind: "level",
+			input: "Supported trace levels for source h1:\n" +
+				"    error      : report errors\n" +
+				"  * user       : also information useful to the end user\n" +
+				"    proto      : also protocol-level updates\n",
+			expected: Setting{Kind: "level", Current: []string{"user"}, Choices: []string{"error", "user", "proto"}},
+		},
+		{
+			name: "sink none",
+			kind: "sink",
+			input: "Supported sinks for source h1 (*=current):\n" +
+				"  * none       : ignore traces\n" +
+				"    buf0       : Buffer for important messages\n",
+			expected: Setting{Kind: "sink", Current: []string{"none"}, Choices: []string{"none", "buf0"}},
+		},
+		{
+			name: "event set",
+			kind: "start",
+			input: "Supported events for source h1 (+=enabled, -=disabled):\n" +
+				"  - now        : don't wait for events, immediately change the state\n" +
+				"  - none       : disable all event types\n" +
+				"  - any        : enable all event types\n" +
+				"  + h1c_new    : new H1 connection\n" +
+				"  - h1c_end    : H1 connection terminated\n" +
+				"  + rx_data    : receipt of any H1 data\n",
+			expected: Setting{
+				Kind:    "start",
+				Current: []string{"h1c_new", "rx_data"},
+				Choices: []string{"now", "none", "any", "h1c_new", "h1c_end", "rx_data"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ParseSetting(tt.kind, tt.input); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ParseSetting() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package traces
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
+	"github.com/knowald/lazyhap/src/views/events"
+)
+
+type Model interface {
+	TableView() string
+	FilterMode() bool
+	FilterInput() string
+	ConfirmMode() bool
+	ConfirmPrompt() string
+	TextInputMode() bool
+	TextInputPrompt() string
+	TextInput() string
+	OpenTraceSource() string
+	// ChangedTraceSources returns the sources whose settings are restored
+	// when leaving the tab.
+	ChangedTraceSources() []string
+	TraceView() string
+	TraceStream() (ring string, paused bool, held, dropped int, ended string)
+	GetViewport() viewport.Model
+	ViewportFilterMode() bool
+	ViewportFilterInput() string
+}
+
+func RenderTab(sb *strings.Builder, m Model, baseStyle lipgloss.Style) {
+	ring, paused, held, dropped, ended := m.TraceStream()
+	if ring == "" {
+		sb.WriteString(baseStyle.Render(m.TableView()))
+	} else {
+		viewport := m.GetViewport()
+		viewport.SetContent(m.TraceView())
+		sb.WriteString(baseStyle.Render(viewport.View()))
+	}
+	sb.WriteString("\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	if m.ConfirmMode() {
+		confirmStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+		sb.WriteString(confirmStyle.Render(m.ConfirmPrompt()))
+		return
+	}
+	if m.TextInputMode() {
+		sb.WriteString(inputStyle.Render(m.TextInputPrompt() + ": " + m.TextInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: next  esc: cancel)"))
+		return
+	}
+	if m.ViewportFilterMode() {
+		sb.WriteString(inputStyle.Render("Filter: " + m.ViewportFilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+	if m.FilterMode() {
+		sb.WriteString(inputStyle.Render("Filter: " + m.FilterInput() + "█"))
+		sb.WriteString(" ")
+		sb.WriteString(hintStyle.Render("(enter: apply  esc: clear)"))
+		return
+	}
+
+	if changed := m.ChangedTraceSources(); len(changed) > 0 {
+		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render("Reverted on leaving: " + strings.Join(changed, ", ")))
+		sb.WriteString("  ")
+	}
+	switch {
+	case ring != "":
+		events.RenderStatus(sb, ring, paused, held, dropped, ended)
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("p: pause/resume  j/k: scroll  /: filter  esc: back to settings"))
+	case m.OpenTraceSource() != "":
+		sb.WriteString(inputStyle.Render(m.OpenTraceSource()))
+		sb.WriteString("  ")
+		sb.WriteString(hintStyle.Render("enter: change setting  S: start  X: stop  t: tail sink  esc: back  r: reload"))
+	default:
+		sb.WriteString(hintStyle.Render("enter: open source  S: start  X: stop  /: filter  r: reload  ?: help"))
+	}
+}